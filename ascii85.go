@@ -0,0 +1,144 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"io"
+	"strings"
+)
+
+// CouldBeAscii85 checks if a given value is a ascii85 string that can be decoded without causing an error.
+// It utilizes the FromAscii85WithErr function to attempt the decoding. Empty strings are not considered ascii85.
+//
+// Parameters:
+//   - a: The value of any type that is assumed to be a ascii85 string and attempted to decode.
+//
+// Returns:
+//   - bool: A boolean value indicating whether the provided value could be decoded from a ascii85 string.
+//
+// Example:
+//
+//	fmt.Println(CouldBeAscii85("87cURDZ")) // true
+//	fmt.Println(CouldBeAscii85("~~~"))     // false
+func CouldBeAscii85(a any) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil || strings.TrimSpace(s) == "" {
+		return false
+	}
+	_, err = FromAscii85WithErr(s)
+	return err == nil
+}
+
+// ToAscii85 converts a given value to a ascii85 string representation, panicking if the conversion fails.
+// See ToAscii85WithErr.
+//
+// Example:
+//
+//	fmt.Println(ToAscii85("Hello")) // 87cURDZ
+func ToAscii85(a any) string {
+	s, err := ToAscii85WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToAscii85WithErr converts a given value to a ascii85 string representation. The value is first converted to a byte
+// slice using ToBytesWithErr, which is then encoded.
+// The output follows the btoa and PDF conventions, without the "<~" and "~>" delimiters.
+//
+// Parameters:
+//   - a: The value of any type to be converted to a ascii85 string.
+//
+// Returns:
+//   - string: The ascii85 encoded string representation of the provided value.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	s, err := ToAscii85WithErr("Hello")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(s) // 87cURDZ
+func ToAscii85WithErr(a any) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return encodeAscii85(bs), nil
+}
+
+// FromAscii85 decodes a ascii85 string, panicking if decoding fails. See FromAscii85WithErr.
+//
+// Example:
+//
+//	fmt.Println(string(FromAscii85("87cURDZ"))) // Hello
+func FromAscii85(a any) []byte {
+	bs, err := FromAscii85WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromAscii85WithErr decodes a ascii85 string. The value is first converted to a string using ToStringWithErr.
+// The "<~" and "~>" delimiters are optional and whitespace is ignored.
+//
+// Parameters:
+//   - a: Any value to be decoded from ascii85. This value is internally converted to a string before decoding.
+//
+// Returns:
+//   - []byte: The byte slice resulting from decoding the ascii85 string.
+//   - error: An error is returned if the value cannot be converted to a string or is not valid ascii85.
+//
+// Example:
+//
+//	bs, err := FromAscii85WithErr("87cURDZ")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(string(bs)) // Hello
+func FromAscii85WithErr(a any) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAscii85(s)
+}
+
+// FromAscii85ToString decodes a ascii85 string into a string, panicking if decoding fails.
+// See FromAscii85ToStringWithErr.
+func FromAscii85ToString(a any) string {
+	s, err := FromAscii85ToStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FromAscii85ToStringWithErr decodes a ascii85 string into a string using FromAscii85WithErr.
+//
+// Example:
+//
+//	s, _ := FromAscii85ToStringWithErr("87cURDZ")
+//	fmt.Println(s) // Hello
+func FromAscii85ToStringWithErr(a any) (string, error) {
+	bs, err := FromAscii85WithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func encodeAscii85(bs []byte) string {
+	dst := make([]byte, ascii85.MaxEncodedLen(len(bs)))
+	return string(dst[:ascii85.Encode(dst, bs)])
+}
+
+func decodeAscii85(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "<~")
+	s = strings.TrimSuffix(s, "~>")
+	return io.ReadAll(ascii85.NewDecoder(bytes.NewReader([]byte(s))))
+}
@@ -0,0 +1,78 @@
+package converter
+
+import (
+	"testing"
+)
+
+type ascii85Case struct {
+	name    string
+	arg     any
+	want    string
+	wantErr bool
+}
+
+func TestCouldBeAscii85(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  any
+		want bool
+	}{
+		{"Valid Ascii85 String", "87cURDZ", true},
+		{"Invalid Ascii85 String", "~~~", false},
+		{"Empty String", "", false},
+		{"Unsupported Type", func() {}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CouldBeAscii85(tc.arg); got != tc.want {
+				t.Errorf("CouldBeAscii85() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToAscii85WithErr(t *testing.T) {
+	testCases := []ascii85Case{
+		{"Encode 1", "Hello", "87cURDZ", false},
+		{"Encode 2", []byte{0, 0, 0, 0}, "z", false},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToAscii85WithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToAscii85WithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToAscii85WithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromAscii85ToStringWithErr(t *testing.T) {
+	testCases := []ascii85Case{
+		{"Round trip 1", "87cURDZ", string(ToBytes("Hello")), false},
+		{"Round trip 2", "z", string(ToBytes([]byte{0, 0, 0, 0})), false},
+		{"Decode 1", "<~87cURDZ~>", "Hello", false},
+		{"Decode 2", "87cU RDZ", "Hello", false},
+		{"Decode 3", "~~~", "", true},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromAscii85ToStringWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromAscii85ToStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("FromAscii85ToStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package converter
+
+import (
+	"encoding/base32"
+	"strings"
+)
+
+// CouldBeBase32 checks if a given value is a base32 string that can be decoded without causing an error.
+// It utilizes the FromBase32WithErr function to attempt the decoding. Empty strings are not considered base32.
+//
+// Parameters:
+//   - a: The value of any type that is assumed to be a base32 string and attempted to decode.
+//
+// Returns:
+//   - bool: A boolean value indicating whether the provided value could be decoded from a base32 string.
+//
+// Example:
+//
+//	fmt.Println(CouldBeBase32("JBSWY3DP")) // true
+//	fmt.Println(CouldBeBase32("1!"))       // false
+func CouldBeBase32(a any) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil || strings.TrimSpace(s) == "" {
+		return false
+	}
+	_, err = FromBase32WithErr(s)
+	return err == nil
+}
+
+// ToBase32 converts a given value to a base32 string representation, panicking if the conversion fails.
+// See ToBase32WithErr.
+//
+// Example:
+//
+//	fmt.Println(ToBase32("Hello")) // JBSWY3DP
+func ToBase32(a any) string {
+	s, err := ToBase32WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToBase32WithErr converts a given value to a base32 string representation. The value is first converted to a byte
+// slice using ToBytesWithErr, which is then encoded.
+// The output uses the standard RFC 4648 alphabet with padding.
+//
+// Parameters:
+//   - a: The value of any type to be converted to a base32 string.
+//
+// Returns:
+//   - string: The base32 encoded string representation of the provided value.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	s, err := ToBase32WithErr("Hello")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(s) // JBSWY3DP
+func ToBase32WithErr(a any) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase32(bs), nil
+}
+
+// FromBase32 decodes a base32 string, panicking if decoding fails. See FromBase32WithErr.
+//
+// Example:
+//
+//	fmt.Println(string(FromBase32("JBSWY3DP"))) // Hello
+func FromBase32(a any) []byte {
+	bs, err := FromBase32WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromBase32WithErr decodes a base32 string. The value is first converted to a string using ToStringWithErr.
+// Decoding is case insensitive, padding is optional and whitespace is ignored, so TOTP secrets such as
+// "jbsw y3dp" are accepted.
+//
+// Parameters:
+//   - a: Any value to be decoded from base32. This value is internally converted to a string before decoding.
+//
+// Returns:
+//   - []byte: The byte slice resulting from decoding the base32 string.
+//   - error: An error is returned if the value cannot be converted to a string or is not valid base32.
+//
+// Example:
+//
+//	bs, err := FromBase32WithErr("JBSWY3DP")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(string(bs)) // Hello
+func FromBase32WithErr(a any) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBase32(s)
+}
+
+// FromBase32ToString decodes a base32 string into a string, panicking if decoding fails.
+// See FromBase32ToStringWithErr.
+func FromBase32ToString(a any) string {
+	s, err := FromBase32ToStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FromBase32ToStringWithErr decodes a base32 string into a string using FromBase32WithErr.
+//
+// Example:
+//
+//	s, _ := FromBase32ToStringWithErr("JBSWY3DP")
+//	fmt.Println(s) // Hello
+func FromBase32ToStringWithErr(a any) (string, error) {
+	bs, err := FromBase32WithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func encodeBase32(bs []byte) string {
+	return base32.StdEncoding.EncodeToString(bs)
+}
+
+func decodeBase32(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.ToUpper(removeWhitespace(s)), "=")
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+}
@@ -0,0 +1,78 @@
+package converter
+
+import (
+	"testing"
+)
+
+type base32Case struct {
+	name    string
+	arg     any
+	want    string
+	wantErr bool
+}
+
+func TestCouldBeBase32(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  any
+		want bool
+	}{
+		{"Valid Base32 String", "JBSWY3DP", true},
+		{"Invalid Base32 String", "1!", false},
+		{"Empty String", "", false},
+		{"Unsupported Type", func() {}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CouldBeBase32(tc.arg); got != tc.want {
+				t.Errorf("CouldBeBase32() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToBase32WithErr(t *testing.T) {
+	testCases := []base32Case{
+		{"Encode 1", "Hello", "JBSWY3DP", false},
+		{"Encode 2", "Hi", "JBUQ====", false},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBase32WithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToBase32WithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToBase32WithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromBase32ToStringWithErr(t *testing.T) {
+	testCases := []base32Case{
+		{"Round trip 1", "JBSWY3DP", string(ToBytes("Hello")), false},
+		{"Round trip 2", "JBUQ====", string(ToBytes("Hi")), false},
+		{"Decode 1", "jbsw y3dp", "Hello", false},
+		{"Decode 2", "JBUQ", "Hi", false},
+		{"Decode 3", "1!", "", true},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBase32ToStringWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromBase32ToStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("FromBase32ToStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package converter
+
+import "strings"
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// CouldBeBase58 checks if a given value is a base58 string that can be decoded without causing an error.
+// It utilizes the FromBase58WithErr function to attempt the decoding. Empty strings are not considered base58.
+//
+// Parameters:
+//   - a: The value of any type that is assumed to be a base58 string and attempted to decode.
+//
+// Returns:
+//   - bool: A boolean value indicating whether the provided value could be decoded from a base58 string.
+//
+// Example:
+//
+//	fmt.Println(CouldBeBase58("9Ajdvzr")) // true
+//	fmt.Println(CouldBeBase58("0OIl"))    // false
+func CouldBeBase58(a any) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil || strings.TrimSpace(s) == "" {
+		return false
+	}
+	_, err = FromBase58WithErr(s)
+	return err == nil
+}
+
+// ToBase58 converts a given value to a base58 string representation, panicking if the conversion fails.
+// See ToBase58WithErr.
+//
+// Example:
+//
+//	fmt.Println(ToBase58("Hello")) // 9Ajdvzr
+func ToBase58(a any) string {
+	s, err := ToBase58WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToBase58WithErr converts a given value to a base58 string representation. The value is first converted to a byte
+// slice using ToBytesWithErr, which is then encoded.
+// The output uses the Bitcoin alphabet, which leaves out the easily confused characters 0, O, I and l.
+// Each leading zero byte is encoded as a '1'.
+//
+// Parameters:
+//   - a: The value of any type to be converted to a base58 string.
+//
+// Returns:
+//   - string: The base58 encoded string representation of the provided value.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	s, err := ToBase58WithErr("Hello")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(s) // 9Ajdvzr
+func ToBase58WithErr(a any) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase58(bs), nil
+}
+
+// FromBase58 decodes a base58 string, panicking if decoding fails. See FromBase58WithErr.
+//
+// Example:
+//
+//	fmt.Println(string(FromBase58("9Ajdvzr"))) // Hello
+func FromBase58(a any) []byte {
+	bs, err := FromBase58WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromBase58WithErr decodes a base58 string. The value is first converted to a string using ToStringWithErr.
+// Each leading '1' is decoded as a zero byte.
+//
+// Parameters:
+//   - a: Any value to be decoded from base58. This value is internally converted to a string before decoding.
+//
+// Returns:
+//   - []byte: The byte slice resulting from decoding the base58 string.
+//   - error: An error is returned if the value cannot be converted to a string or is not valid base58.
+//
+// Example:
+//
+//	bs, err := FromBase58WithErr("9Ajdvzr")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(string(bs)) // Hello
+func FromBase58WithErr(a any) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBase58(s)
+}
+
+// FromBase58ToString decodes a base58 string into a string, panicking if decoding fails.
+// See FromBase58ToStringWithErr.
+func FromBase58ToString(a any) string {
+	s, err := FromBase58ToStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FromBase58ToStringWithErr decodes a base58 string into a string using FromBase58WithErr.
+//
+// Example:
+//
+//	s, _ := FromBase58ToStringWithErr("9Ajdvzr")
+//	fmt.Println(s) // Hello
+func FromBase58ToStringWithErr(a any) (string, error) {
+	bs, err := FromBase58WithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func encodeBase58(bs []byte) string {
+	return encodeBaseX(bs, base58Alphabet)
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	return decodeBaseX(strings.TrimSpace(s), base58Alphabet, "base58")
+}
@@ -0,0 +1,77 @@
+package converter
+
+import (
+	"testing"
+)
+
+type base58Case struct {
+	name    string
+	arg     any
+	want    string
+	wantErr bool
+}
+
+func TestCouldBeBase58(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  any
+		want bool
+	}{
+		{"Valid Base58 String", "9Ajdvzr", true},
+		{"Invalid Base58 String", "0OIl", false},
+		{"Empty String", "", false},
+		{"Unsupported Type", func() {}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CouldBeBase58(tc.arg); got != tc.want {
+				t.Errorf("CouldBeBase58() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToBase58WithErr(t *testing.T) {
+	testCases := []base58Case{
+		{"Encode 1", "Hello", "9Ajdvzr", false},
+		{"Encode 2", []byte{0, 0, 1}, "112", false},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBase58WithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToBase58WithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToBase58WithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromBase58ToStringWithErr(t *testing.T) {
+	testCases := []base58Case{
+		{"Round trip 1", "9Ajdvzr", string(ToBytes("Hello")), false},
+		{"Round trip 2", "112", string(ToBytes([]byte{0, 0, 1})), false},
+		{"Decode 1", "112", "\x00\x00\x01", false},
+		{"Decode 2", "0OIl", "", true},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBase58ToStringWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromBase58ToStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("FromBase58ToStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package converter
+
+import "strings"
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// CouldBeBase62 checks if a given value is a base62 string that can be decoded without causing an error.
+// It utilizes the FromBase62WithErr function to attempt the decoding. Empty strings are not considered base62.
+//
+// Parameters:
+//   - a: The value of any type that is assumed to be a base62 string and attempted to decode.
+//
+// Returns:
+//   - bool: A boolean value indicating whether the provided value could be decoded from a base62 string.
+//
+// Example:
+//
+//	fmt.Println(CouldBeBase62("5TP3P3v")) // true
+//	fmt.Println(CouldBeBase62("a-b"))     // false
+func CouldBeBase62(a any) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil || strings.TrimSpace(s) == "" {
+		return false
+	}
+	_, err = FromBase62WithErr(s)
+	return err == nil
+}
+
+// ToBase62 converts a given value to a base62 string representation, panicking if the conversion fails.
+// See ToBase62WithErr.
+//
+// Example:
+//
+//	fmt.Println(ToBase62("Hello")) // 5TP3P3v
+func ToBase62(a any) string {
+	s, err := ToBase62WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToBase62WithErr converts a given value to a base62 string representation. The value is first converted to a byte
+// slice using ToBytesWithErr, which is then encoded.
+// The output uses the alphabet 0-9, A-Z and a-z, which is safe in URLs without escaping, handy for short
+// links. Each leading zero byte is encoded as a '0'.
+//
+// Parameters:
+//   - a: The value of any type to be converted to a base62 string.
+//
+// Returns:
+//   - string: The base62 encoded string representation of the provided value.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	s, err := ToBase62WithErr("Hello")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(s) // 5TP3P3v
+func ToBase62WithErr(a any) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase62(bs), nil
+}
+
+// FromBase62 decodes a base62 string, panicking if decoding fails. See FromBase62WithErr.
+//
+// Example:
+//
+//	fmt.Println(string(FromBase62("5TP3P3v"))) // Hello
+func FromBase62(a any) []byte {
+	bs, err := FromBase62WithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromBase62WithErr decodes a base62 string. The value is first converted to a string using ToStringWithErr.
+// Each leading '0' is decoded as a zero byte.
+//
+// Parameters:
+//   - a: Any value to be decoded from base62. This value is internally converted to a string before decoding.
+//
+// Returns:
+//   - []byte: The byte slice resulting from decoding the base62 string.
+//   - error: An error is returned if the value cannot be converted to a string or is not valid base62.
+//
+// Example:
+//
+//	bs, err := FromBase62WithErr("5TP3P3v")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(string(bs)) // Hello
+func FromBase62WithErr(a any) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBase62(s)
+}
+
+// FromBase62ToString decodes a base62 string into a string, panicking if decoding fails.
+// See FromBase62ToStringWithErr.
+func FromBase62ToString(a any) string {
+	s, err := FromBase62ToStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FromBase62ToStringWithErr decodes a base62 string into a string using FromBase62WithErr.
+//
+// Example:
+//
+//	s, _ := FromBase62ToStringWithErr("5TP3P3v")
+//	fmt.Println(s) // Hello
+func FromBase62ToStringWithErr(a any) (string, error) {
+	bs, err := FromBase62WithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func encodeBase62(bs []byte) string {
+	return encodeBaseX(bs, base62Alphabet)
+}
+
+func decodeBase62(s string) ([]byte, error) {
+	return decodeBaseX(strings.TrimSpace(s), base62Alphabet, "base62")
+}
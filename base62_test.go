@@ -0,0 +1,77 @@
+package converter
+
+import (
+	"testing"
+)
+
+type base62Case struct {
+	name    string
+	arg     any
+	want    string
+	wantErr bool
+}
+
+func TestCouldBeBase62(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  any
+		want bool
+	}{
+		{"Valid Base62 String", "5TP3P3v", true},
+		{"Invalid Base62 String", "a-b", false},
+		{"Empty String", "", false},
+		{"Unsupported Type", func() {}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CouldBeBase62(tc.arg); got != tc.want {
+				t.Errorf("CouldBeBase62() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToBase62WithErr(t *testing.T) {
+	testCases := []base62Case{
+		{"Encode 1", "Hello", "5TP3P3v", false},
+		{"Encode 2", []byte{0, 61}, "0z", false},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBase62WithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToBase62WithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToBase62WithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromBase62ToStringWithErr(t *testing.T) {
+	testCases := []base62Case{
+		{"Round trip 1", "5TP3P3v", string(ToBytes("Hello")), false},
+		{"Round trip 2", "0z", string(ToBytes([]byte{0, 61})), false},
+		{"Decode 1", "0z", "\x00=", false},
+		{"Decode 2", "a-b", "", true},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBase62ToStringWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromBase62ToStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("FromBase62ToStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,111 @@
 package converter
 
-import "encoding/base64"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
 
 // TODO: Adicionar exemplos
 
-// CouldBeBase64 checks if a given value can be decoded from a base64 string without causing an error.
-// It utilizes the FromBase64WithErr function to attempt a conversion from base64.
-// If decoding is successful, the function returns true, otherwise it returns false.
+// Base64Variant identifies a base64 alphabet, padding and line wrapping combination.
+type Base64Variant int
+
+const (
+	// Base64Std is the standard alphabet with padding, as defined in RFC 4648 section 4.
+	Base64Std Base64Variant = iota
+	// Base64RawStd is the standard alphabet without padding.
+	Base64RawStd
+	// Base64URL is the URL and filename safe alphabet with padding, as defined in RFC 4648 section 5.
+	Base64URL
+	// Base64RawURL is the URL and filename safe alphabet without padding, used by JWTs.
+	Base64RawURL
+	// Base64MIME is the standard alphabet with padding wrapped in lines of 76 characters separated by CRLF, as
+	// defined in RFC 2045 for email attachments.
+	Base64MIME
+)
+
+const base64MIMELineLength = 76
+
+// String returns the name of the variant.
+func (v Base64Variant) String() string {
+	switch v {
+	case Base64Std:
+		return "std"
+	case Base64RawStd:
+		return "raw-std"
+	case Base64URL:
+		return "url"
+	case Base64RawURL:
+		return "raw-url"
+	case Base64MIME:
+		return "mime"
+	default:
+		return fmt.Sprintf("Base64Variant(%d)", int(v))
+	}
+}
+
+func (v Base64Variant) encoding() (*base64.Encoding, error) {
+	switch v {
+	case Base64Std, Base64MIME:
+		return base64.StdEncoding, nil
+	case Base64RawStd:
+		return base64.RawStdEncoding, nil
+	case Base64URL:
+		return base64.URLEncoding, nil
+	case Base64RawURL:
+		return base64.RawURLEncoding, nil
+	default:
+		return nil, fmt.Errorf("unsupported base64 variant %s", v)
+	}
+}
+
+// Base64Options configures how CouldBeBase64WithOptions validates a base64 string.
+type Base64Options struct {
+	// Variant is the variant the input must be encoded in. It is ignored when AnyVariant is set.
+	Variant Base64Variant
+	// AnyVariant accepts the input in any variant, as detected by DetectBase64VariantWithErr, ignoring whitespace.
+	AnyVariant bool
+	// Strict rejects non-canonical encodings, those whose padding bits are not zero, so that only the exact output
+	// of an encoder is accepted.
+	Strict bool
+	// MinLength is the minimum number of base64 characters, excluding whitespace, the input must have.
+	MinLength int
+}
+
+// Base64Payload describes the content decoded from a base64 string.
+type Base64Payload int
+
+const (
+	// Base64PayloadBinary is decoded content that is not valid UTF-8.
+	Base64PayloadBinary Base64Payload = iota
+	// Base64PayloadText is decoded content that is valid UTF-8 text.
+	Base64PayloadText
+	// Base64PayloadJSON is decoded content that is a valid JSON document.
+	Base64PayloadJSON
+)
+
+// String returns the name of the payload kind.
+func (p Base64Payload) String() string {
+	switch p {
+	case Base64PayloadBinary:
+		return "binary"
+	case Base64PayloadText:
+		return "text"
+	case Base64PayloadJSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Base64Payload(%d)", int(p))
+	}
+}
+
+// CouldBeBase64 checks if a given value is a base64 string that can be decoded without causing an error.
+// It attempts to decode the value as standard, padded base64, the same format accepted by FromBase64WithErr.
+// If decoding is successful, the function returns true, otherwise it returns false. Empty strings are not
+// considered base64.
 //
 // Parameters:
 //   - a: The value of any type that is assumed to be a base64 string and attempted to decode.
@@ -21,10 +120,79 @@ import "encoding/base64"
 //	fmt.Println(CouldBeBase64(x)) // true
 //	fmt.Println(CouldBeBase64(y)) // false
 func CouldBeBase64(a any) bool {
-	_, err := ToBase64WithErr(a)
+	return CouldBeBase64WithOptions(a, Base64Options{MinLength: 1})
+}
+
+// CouldBeBase64WithOptions checks if a given value is a base64 string that can be decoded according to the given
+// options, see Base64Options.
+//
+// Example:
+//
+//	fmt.Println(CouldBeBase64WithOptions("Zm9=", Base64Options{}))                // true
+//	fmt.Println(CouldBeBase64WithOptions("Zm9=", Base64Options{Strict: true}))    // false, "Zm8=" is canonical
+//	fmt.Println(CouldBeBase64WithOptions("-_8", Base64Options{AnyVariant: true})) // true
+//	fmt.Println(CouldBeBase64WithOptions("YQ==", Base64Options{MinLength: 8}))    // false
+func CouldBeBase64WithOptions(a any, opts Base64Options) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return false
+	}
+
+	cleaned, variant := s, opts.Variant
+	if opts.AnyVariant {
+		cleaned = removeWhitespace(s)
+		if variant, err = detectBase64Variant(s, cleaned); err != nil {
+			return false
+		}
+	}
+	if len(removeWhitespace(cleaned)) < opts.MinLength {
+		return false
+	}
+
+	encoding, err := variant.encoding()
+	if err != nil {
+		return false
+	} else if opts.Strict {
+		encoding = encoding.Strict()
+	}
+	_, err = encoding.DecodeString(cleaned)
 	return err == nil
 }
 
+// DetectBase64Payload decodes a base64 string in any variant and reports what kind of content it holds, panicking
+// if it is not base64. See DetectBase64PayloadWithErr.
+func DetectBase64Payload(a any) Base64Payload {
+	p, err := DetectBase64PayloadWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// DetectBase64PayloadWithErr decodes a base64 string in any variant, using FromBase64AutoWithErr, and reports
+// whether the decoded content is a JSON document, UTF-8 text or binary data. It is useful to sniff fields that may
+// or may not be encoded.
+//
+// Example:
+//
+//	fmt.Println(DetectBase64PayloadWithErr("eyJhIjoxfQ==")) // json <nil>
+//	fmt.Println(DetectBase64PayloadWithErr("aGVsbG8="))     // text <nil>
+//	fmt.Println(DetectBase64PayloadWithErr("+/8="))         // binary <nil>
+func DetectBase64PayloadWithErr(a any) (Base64Payload, error) {
+	bs, err := FromBase64AutoWithErr(a)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case len(bs) > 0 && json.Valid(bs):
+		return Base64PayloadJSON, nil
+	case utf8.Valid(bs):
+		return Base64PayloadText, nil
+	default:
+		return Base64PayloadBinary, nil
+	}
+}
+
 // ToBase64 attempts to convert a given value to a base64 string representation. The function leverages
 // ToBase64WithErr to handle the conversion. If the conversion fails, the function panics.
 //
@@ -237,3 +405,259 @@ func FromBase64ToStringWithErr(a any) (string, error) {
 	}
 	return string(bs), nil
 }
+
+// ToBase64URL converts the given value to a base64 string using the URL and filename safe alphabet with padding,
+// panicking if the conversion fails. See ToBase64VariantWithErr.
+func ToBase64URL(a any) string {
+	return ToBase64Variant(a, Base64URL)
+}
+
+// ToBase64URLWithErr converts the given value to a base64 string using the URL and filename safe alphabet with
+// padding. See ToBase64VariantWithErr.
+func ToBase64URLWithErr(a any) (string, error) {
+	return ToBase64VariantWithErr(a, Base64URL)
+}
+
+// ToBase64RawURL converts the given value to a base64 string using the URL and filename safe alphabet without
+// padding, as used by JWTs, panicking if the conversion fails. See ToBase64VariantWithErr.
+func ToBase64RawURL(a any) string {
+	return ToBase64Variant(a, Base64RawURL)
+}
+
+// ToBase64RawURLWithErr converts the given value to a base64 string using the URL and filename safe alphabet
+// without padding, as used by JWTs. See ToBase64VariantWithErr.
+func ToBase64RawURLWithErr(a any) (string, error) {
+	return ToBase64VariantWithErr(a, Base64RawURL)
+}
+
+// ToBase64Raw converts the given value to a base64 string using the standard alphabet without padding, panicking
+// if the conversion fails. See ToBase64VariantWithErr.
+func ToBase64Raw(a any) string {
+	return ToBase64Variant(a, Base64RawStd)
+}
+
+// ToBase64RawWithErr converts the given value to a base64 string using the standard alphabet without padding.
+// See ToBase64VariantWithErr.
+func ToBase64RawWithErr(a any) (string, error) {
+	return ToBase64VariantWithErr(a, Base64RawStd)
+}
+
+// ToBase64MIME converts the given value to a base64 string wrapped in lines of 76 characters, as used by email
+// attachments, panicking if the conversion fails. See ToBase64VariantWithErr.
+func ToBase64MIME(a any) string {
+	return ToBase64Variant(a, Base64MIME)
+}
+
+// ToBase64MIMEWithErr converts the given value to a base64 string wrapped in lines of 76 characters separated by
+// CRLF, as used by email attachments. See ToBase64VariantWithErr.
+func ToBase64MIMEWithErr(a any) (string, error) {
+	return ToBase64VariantWithErr(a, Base64MIME)
+}
+
+// ToBase64Variant converts the given value to a base64 string in the given variant, panicking if the conversion
+// fails. See ToBase64VariantWithErr.
+func ToBase64Variant(a any, variant Base64Variant) string {
+	s, err := ToBase64VariantWithErr(a, variant)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToBase64VariantWithErr converts the given value to a base64 string in the given variant. Like ToBase64WithErr,
+// the value is first converted to a byte slice using ToBytesWithErr.
+//
+// Parameters:
+//   - a: The value of any type to be converted to a base64 string.
+//   - variant: The alphabet, padding and line wrapping to use.
+//
+// Returns:
+//   - string: The base64 encoded string representation of the provided value.
+//   - error: An error is returned in case of failure to convert or if the variant is unknown.
+//
+// Example:
+//
+//	s, _ := ToBase64VariantWithErr([]byte{0xfb, 0xff}, Base64RawURL)
+//	fmt.Println(s) // -_8
+//	s, _ = ToBase64VariantWithErr([]byte{0xfb, 0xff}, Base64Std)
+//	fmt.Println(s) // +/8=
+func ToBase64VariantWithErr(a any, variant Base64Variant) (string, error) {
+	encoding, err := variant.encoding()
+	if err != nil {
+		return "", err
+	}
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+
+	s := encoding.EncodeToString(bs)
+	if variant == Base64MIME {
+		s = wrapBase64Lines(s)
+	}
+	return s, nil
+}
+
+// FromBase64Variant decodes a base64 string in the given variant, panicking if decoding fails.
+// See FromBase64VariantWithErr.
+func FromBase64Variant(a any, variant Base64Variant) []byte {
+	bs, err := FromBase64VariantWithErr(a, variant)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromBase64VariantWithErr decodes a base64 string in the given variant. The value is first converted to a string
+// using ToStringWithErr. Line breaks are ignored, as in every variant of the encoding/base64 package.
+//
+// Example:
+//
+//	bs, _ := FromBase64VariantWithErr("-_8", Base64RawURL)
+//	fmt.Println(bs) // [251 255]
+func FromBase64VariantWithErr(a any, variant Base64Variant) ([]byte, error) {
+	encoding, err := variant.encoding()
+	if err != nil {
+		return nil, err
+	}
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return encoding.DecodeString(s)
+}
+
+// FromBase64Auto decodes a base64 string in any variant, panicking if decoding fails.
+// See FromBase64AutoWithErr.
+func FromBase64Auto(a any) []byte {
+	bs, err := FromBase64AutoWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromBase64AutoWithErr decodes a base64 string without knowing its variant. The alphabet and padding are detected
+// with DetectBase64VariantWithErr and any whitespace, including the line breaks of MIME base64, is ignored.
+//
+// Example:
+//
+//	bs, _ := FromBase64AutoWithErr("SGVsbG8gd29y\r\nbGQ=")
+//	fmt.Println(string(bs)) // Hello world
+//	bs, _ = FromBase64AutoWithErr("SGVsbG8gd29ybGQ")
+//	fmt.Println(string(bs)) // Hello world
+func FromBase64AutoWithErr(a any) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	cleaned := removeWhitespace(s)
+	variant, err := detectBase64Variant(s, cleaned)
+	if err != nil {
+		return nil, err
+	}
+	encoding, err := variant.encoding()
+	if err != nil {
+		return nil, err
+	}
+	return encoding.DecodeString(cleaned)
+}
+
+// DetectBase64Variant returns the variant of the given base64 string, panicking if it is not base64.
+// See DetectBase64VariantWithErr.
+func DetectBase64Variant(a any) Base64Variant {
+	variant, err := DetectBase64VariantWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return variant
+}
+
+// DetectBase64VariantWithErr reports which variant the given base64 string was encoded in, based on its alphabet,
+// padding and line breaks. When the input is valid in more than one variant, e.g. it has neither URL nor standard
+// specific characters, the standard variants are preferred.
+//
+// Detection:
+//   - Line breaks inside the input: Base64MIME.
+//   - '-' or '_' characters: Base64URL when padded, Base64RawURL otherwise.
+//   - Any other input: Base64Std when padded or a multiple of 4 characters long, Base64RawStd otherwise.
+//
+// An error is returned if the input mixes both alphabets, has characters outside of them or cannot be decoded in
+// the detected variant.
+//
+// Example:
+//
+//	fmt.Println(DetectBase64VariantWithErr("eyJhbGciOiJIUzI1NiJ9")) // std <nil>
+//	fmt.Println(DetectBase64VariantWithErr("-_8"))                  // raw-url <nil>
+func DetectBase64VariantWithErr(a any) (Base64Variant, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return 0, err
+	}
+	cleaned := removeWhitespace(s)
+	variant, err := detectBase64Variant(s, cleaned)
+	if err != nil {
+		return 0, err
+	}
+	encoding, _ := variant.encoding()
+	if _, err = encoding.DecodeString(cleaned); err != nil {
+		return 0, err
+	}
+	return variant, nil
+}
+
+func detectBase64Variant(original, cleaned string) (Base64Variant, error) {
+	if cleaned == "" {
+		return 0, errors.New("error detecting base64 variant, it is empty")
+	}
+
+	hasStd, hasURL := false, false
+	for i, r := range cleaned {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '+' || r == '/':
+			hasStd = true
+		case r == '-' || r == '_':
+			hasURL = true
+		case r == '=' && i >= len(cleaned)-2:
+		default:
+			return 0, fmt.Errorf("illegal base64 data at input byte %d", i)
+		}
+	}
+
+	padded := strings.HasSuffix(cleaned, "=")
+	switch {
+	case hasStd && hasURL:
+		return 0, errors.New("error detecting base64 variant, it mixes standard and URL alphabets")
+	case hasURL && padded:
+		return Base64URL, nil
+	case hasURL:
+		return Base64RawURL, nil
+	case strings.ContainsAny(strings.TrimSpace(original), "\r\n"):
+		return Base64MIME, nil
+	case padded || len(cleaned)%4 == 0:
+		return Base64Std, nil
+	default:
+		return Base64RawStd, nil
+	}
+}
+
+func wrapBase64Lines(s string) string {
+	var sb strings.Builder
+	for len(s) > base64MIMELineLength {
+		sb.WriteString(s[:base64MIMELineLength])
+		sb.WriteString("\r\n")
+		s = s[base64MIMELineLength:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
+
+func removeWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' || r == '\f' || r == '\v' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -17,8 +18,12 @@ func TestCouldBeBase64(t *testing.T) {
 		arg  any
 		want bool
 	}{
-		{"Valid Base64 String", "not base64, too!!", true},
-		{"Invalid Base64 String", func() {}, false},
+		{"Valid Base64 String", "SGVsbG8gd29ybGQ=", true},
+		{"Invalid Base64 String", "not base64, too!!", false},
+		{"Not Base64 Word", "NotBase64", false},
+		{"Raw Base64 String", "SGVsbG8gd29ybGQ", false},
+		{"Empty String", "", false},
+		{"Unsupported Type", func() {}, false},
 	}
 
 	for _, tc := range testCases {
@@ -98,3 +103,163 @@ func initFromBase64TestsCase() []base64Case {
 		{"Error", func() {}, "Z29sYW5n", true},
 	}
 }
+
+func TestToBase64VariantWithErr(t *testing.T) {
+	payload := []byte{0xfb, 0xff, 0xfe}
+
+	testCases := []struct {
+		name    string
+		variant Base64Variant
+		arg     any
+		want    string
+		wantErr bool
+	}{
+		{"Std", Base64Std, payload[:2], "+/8=", false},
+		{"Raw std", Base64RawStd, payload[:2], "+/8", false},
+		{"URL", Base64URL, payload[:2], "-_8=", false},
+		{"Raw URL", Base64RawURL, payload[:2], "-_8", false},
+		{"MIME short", Base64MIME, "golang", "Z29sYW5n", false},
+		{"Unknown variant", Base64Variant(99), "golang", "", true},
+		{"Error", Base64Std, func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBase64VariantWithErr(tc.arg, tc.variant)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToBase64VariantWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToBase64VariantWithErr() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToBase64MIME(t *testing.T) {
+	got := ToBase64MIME(strings.Repeat("a", 100))
+	lines := strings.Split(got, "\r\n")
+	if len(lines) != 2 || len(lines[0]) != 76 {
+		t.Errorf("ToBase64MIME() = %q, want two CRLF separated lines of at most 76 characters", got)
+	}
+	if back := string(FromBase64Auto(got)); back != strings.Repeat("a", 100) {
+		t.Errorf("FromBase64Auto() = %q", back)
+	}
+}
+
+func TestFromBase64AutoWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     any
+		want    string
+		wantErr bool
+	}{
+		{"Std", "SGVsbG8gd29ybGQ=", "Hello world", false},
+		{"Raw std", "SGVsbG8gd29ybGQ", "Hello world", false},
+		{"URL", "-_8=", "\xfb\xff", false},
+		{"Raw URL", "-_8", "\xfb\xff", false},
+		{"MIME", "SGVsbG8g\r\nd29ybGQ=\r\n", "Hello world", false},
+		{"Whitespace", " SGVs bG8g\td29y bGQ= ", "Hello world", false},
+		{"Mixed alphabets", "+_8=", "", true},
+		{"Invalid", "NotBase64!", "", true},
+		{"Empty", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBase64AutoWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromBase64AutoWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if string(got) != tc.want {
+				t.Errorf("FromBase64AutoWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetectBase64VariantWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     any
+		want    Base64Variant
+		wantErr bool
+	}{
+		{"Std", "+/8=", Base64Std, false},
+		{"Std without special characters", "Z29sYW5n", Base64Std, false},
+		{"Raw std", "SGVsbG8gd29ybGQ", Base64RawStd, false},
+		{"URL", "-_8=", Base64URL, false},
+		{"Raw URL", "eyJhbGciOiJIUzI1NiJ9-_8", Base64RawURL, false},
+		{"MIME", "SGVsbG8g\r\nd29ybGQ=", Base64MIME, false},
+		{"Invalid", "NotBase64", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DetectBase64VariantWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("DetectBase64VariantWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("DetectBase64VariantWithErr() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCouldBeBase64WithOptions(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  any
+		opts Base64Options
+		want bool
+	}{
+		{"Non canonical", "Zm9=", Base64Options{}, true},
+		{"Non canonical strict", "Zm9=", Base64Options{Strict: true}, false},
+		{"Canonical strict", "Zm8=", Base64Options{Strict: true}, true},
+		{"Raw URL explicit", "-_8", Base64Options{Variant: Base64RawURL}, true},
+		{"Raw URL as std", "-_8", Base64Options{}, false},
+		{"Any variant", "-_8", Base64Options{AnyVariant: true}, true},
+		{"Any variant MIME", "SGVsbG8g\r\nd29ybGQ=", Base64Options{AnyVariant: true}, true},
+		{"Min length", "YQ==", Base64Options{MinLength: 8}, false},
+		{"Min length reached", "SGVsbG8gd29ybGQ=", Base64Options{MinLength: 8}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CouldBeBase64WithOptions(tc.arg, tc.opts); got != tc.want {
+				t.Errorf("CouldBeBase64WithOptions() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetectBase64PayloadWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		arg     any
+		want    Base64Payload
+		wantErr bool
+	}{
+		{"JSON", "eyJhIjoxfQ==", Base64PayloadJSON, false},
+		{"Text", "aGVsbG8=", Base64PayloadText, false},
+		{"Binary", "+/8=", Base64PayloadBinary, false},
+		{"Invalid", "NotBase64!", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DetectBase64PayloadWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("DetectBase64PayloadWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("DetectBase64PayloadWithErr() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package converter
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+// base64StreamChunkSize is the number of input bytes encoded at a time, a multiple of 3 so that only the last chunk
+// needs padding.
+const base64StreamChunkSize = 3 * 1024
+
+// NewBase64Encoder returns a writer that encodes everything written to it as base64 in the given variant and
+// writes the result to w. Memory usage is bounded regardless of how much is written.
+//
+// The returned writer must be closed to flush the last partial block and its padding. Closing it does not close w.
+// An unknown variant results in an error on the first call to Write or Close.
+//
+// Example:
+//
+//	file, _ := os.Open("report.pdf")
+//	encoder := NewBase64Encoder(body, Base64Std)
+//	if _, err := io.Copy(encoder, file); err != nil {
+//		return err
+//	}
+//	return encoder.Close()
+func NewBase64Encoder(w io.Writer, variant Base64Variant) io.WriteCloser {
+	encoding, err := variant.encoding()
+	if err != nil {
+		return &base64ErrWriter{err: err}
+	}
+	if variant == Base64MIME {
+		w = &base64LineWriter{w: w}
+	}
+	return base64.NewEncoder(encoding, w)
+}
+
+// ToBase64Reader returns a reader that yields the base64 encoding, in the given variant, of everything read from
+// src. It is the pull counterpart of NewBase64Encoder, handy to stream an upload body without loading the file
+// in memory. An unknown variant results in an error on the first call to Read.
+//
+// Example:
+//
+//	file, _ := os.Open("report.pdf")
+//	resp, err := http.Post(url, "text/plain", ToBase64Reader(file, Base64Std))
+func ToBase64Reader(src io.Reader, variant Base64Variant) io.Reader {
+	encoding, err := variant.encoding()
+	if err != nil {
+		return &base64ErrReader{err: err}
+	}
+	r := &base64EncodeReader{src: src, encoding: encoding, in: make([]byte, base64StreamChunkSize)}
+	if variant == Base64MIME {
+		r.wrapper = &base64LineWrapper{}
+	}
+	return r
+}
+
+// FromBase64Reader returns a reader that decodes the base64 data, in the given variant, read from src. Any
+// whitespace, including the line breaks of MIME base64, is ignored. Memory usage is bounded regardless of the
+// input size. An unknown variant results in an error on the first call to Read.
+//
+// Example:
+//
+//	decoded := FromBase64Reader(resp.Body, Base64MIME)
+//	_, err := io.Copy(file, decoded)
+func FromBase64Reader(src io.Reader, variant Base64Variant) io.Reader {
+	encoding, err := variant.encoding()
+	if err != nil {
+		return &base64ErrReader{err: err}
+	}
+	return base64.NewDecoder(encoding, &base64FilterReader{src: src})
+}
+
+// FromBase64AutoReader returns a reader that decodes base64 data read from src without knowing its variant, the
+// streaming counterpart of FromBase64AutoWithErr. Both alphabets are accepted, padding is optional and any
+// whitespace is ignored.
+func FromBase64AutoReader(src io.Reader) io.Reader {
+	return base64.NewDecoder(base64.RawStdEncoding, &base64FilterReader{src: src, normalize: true})
+}
+
+// CopyToBase64 encodes everything read from src as base64 in the given variant and writes it to dst, returning
+// the number of encoded bytes written.
+//
+// Example:
+//
+//	n, err := CopyToBase64(os.Stdout, file, Base64RawURL)
+func CopyToBase64(dst io.Writer, src io.Reader, variant Base64Variant) (int64, error) {
+	return io.Copy(dst, ToBase64Reader(src, variant))
+}
+
+// CopyFromBase64 decodes the base64 data, in the given variant, read from src and writes it to dst, returning the
+// number of decoded bytes written.
+//
+// Example:
+//
+//	n, err := CopyFromBase64(file, req.Body, Base64Std)
+func CopyFromBase64(dst io.Writer, src io.Reader, variant Base64Variant) (int64, error) {
+	return io.Copy(dst, FromBase64Reader(src, variant))
+}
+
+type base64EncodeReader struct {
+	src      io.Reader
+	encoding *base64.Encoding
+	wrapper  *base64LineWrapper
+	in       []byte
+	out      []byte
+	pending  []byte
+	done     bool
+	err      error
+}
+
+func (r *base64EncodeReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, r.err
+		}
+
+		n, err := io.ReadFull(r.src, r.in)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			r.done, r.err = true, io.EOF
+		} else if err != nil {
+			r.done, r.err = true, err
+			n = 0
+		}
+
+		size := r.encoding.EncodedLen(n)
+		if cap(r.out) < size {
+			r.out = make([]byte, size)
+		}
+		r.out = r.out[:size]
+		r.encoding.Encode(r.out, r.in[:n])
+		r.pending = r.out
+		if r.wrapper != nil {
+			r.pending = r.wrapper.wrap(r.out)
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+type base64FilterReader struct {
+	src       io.Reader
+	normalize bool
+}
+
+func (r *base64FilterReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.src.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			switch b {
+			case ' ', '\t', '\r', '\n', '\f', '\v':
+				continue
+			case '=':
+				if r.normalize {
+					continue
+				}
+			case '-':
+				if r.normalize {
+					b = '+'
+				}
+			case '_':
+				if r.normalize {
+					b = '/'
+				}
+			}
+			p[kept] = b
+			kept++
+		}
+		if kept > 0 || err != nil || len(p) == 0 {
+			return kept, err
+		}
+	}
+}
+
+type base64LineWrapper struct {
+	column int
+	buf    []byte
+}
+
+func (w *base64LineWrapper) wrap(src []byte) []byte {
+	w.buf = w.buf[:0]
+	for len(src) > 0 {
+		if w.column == base64MIMELineLength {
+			w.buf = append(w.buf, '\r', '\n')
+			w.column = 0
+		}
+		n := min(base64MIMELineLength-w.column, len(src))
+		w.buf = append(w.buf, src[:n]...)
+		w.column += n
+		src = src[n:]
+	}
+	return w.buf
+}
+
+type base64LineWriter struct {
+	w       io.Writer
+	wrapper base64LineWrapper
+}
+
+func (w *base64LineWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(w.wrapper.wrap(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type base64ErrReader struct {
+	err error
+}
+
+func (r *base64ErrReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+type base64ErrWriter struct {
+	err error
+}
+
+func (w *base64ErrWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func (w *base64ErrWriter) Close() error {
+	return w.err
+}
@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestToBase64Reader(t *testing.T) {
+	payload := make([]byte, 3*base64StreamChunkSize+7)
+	rand.New(rand.NewSource(1)).Read(payload)
+
+	for _, variant := range []Base64Variant{Base64Std, Base64RawStd, Base64URL, Base64RawURL, Base64MIME} {
+		t.Run(variant.String(), func(t *testing.T) {
+			got, err := io.ReadAll(ToBase64Reader(bytes.NewReader(payload), variant))
+			if err != nil {
+				t.Fatalf("ToBase64Reader() error = %v", err)
+			}
+			if want := ToBase64Variant(payload, variant); string(got) != want {
+				t.Errorf("ToBase64Reader() differs from ToBase64Variant()")
+			}
+		})
+	}
+}
+
+func TestNewBase64Encoder(t *testing.T) {
+	payload := []byte(strings.Repeat("streaming base64 ", 500))
+
+	for _, variant := range []Base64Variant{Base64Std, Base64RawURL, Base64MIME} {
+		t.Run(variant.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := NewBase64Encoder(&buf, variant)
+			for i := 0; i < len(payload); i += 100 {
+				if _, err := encoder.Write(payload[i:min(i+100, len(payload))]); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if want := ToBase64Variant(payload, variant); buf.String() != want {
+				t.Errorf("NewBase64Encoder() differs from ToBase64Variant()")
+			}
+		})
+	}
+
+	if _, err := NewBase64Encoder(io.Discard, Base64Variant(99)).Write([]byte("a")); err == nil {
+		t.Error("Write() expected error for unknown variant")
+	}
+}
+
+func TestFromBase64Reader(t *testing.T) {
+	payload := make([]byte, 2*base64StreamChunkSize+1)
+	rand.New(rand.NewSource(2)).Read(payload)
+
+	for _, variant := range []Base64Variant{Base64Std, Base64RawStd, Base64URL, Base64RawURL, Base64MIME} {
+		t.Run(variant.String(), func(t *testing.T) {
+			encoded := ToBase64Variant(payload, variant)
+
+			got, err := io.ReadAll(FromBase64Reader(strings.NewReader(encoded), variant))
+			if err != nil {
+				t.Fatalf("FromBase64Reader() error = %v", err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Error("FromBase64Reader() did not round trip")
+			}
+
+			got, err = io.ReadAll(FromBase64AutoReader(strings.NewReader(encoded)))
+			if err != nil {
+				t.Fatalf("FromBase64AutoReader() error = %v", err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Error("FromBase64AutoReader() did not round trip")
+			}
+		})
+	}
+
+	if _, err := io.ReadAll(FromBase64Reader(strings.NewReader("NotBase64!"), Base64Std)); err == nil {
+		t.Error("FromBase64Reader() expected error")
+	}
+}
+
+func TestCopyBase64(t *testing.T) {
+	var encoded, decoded bytes.Buffer
+
+	n, err := CopyToBase64(&encoded, strings.NewReader("Hello world"), Base64Std)
+	if err != nil || n != 16 || encoded.String() != "SGVsbG8gd29ybGQ=" {
+		t.Fatalf("CopyToBase64() = %d, %v, %q", n, err, encoded.String())
+	}
+
+	n, err = CopyFromBase64(&decoded, strings.NewReader(" SGVsbG8g\r\nd29ybGQ= "), Base64Std)
+	if err != nil || n != 11 || decoded.String() != "Hello world" {
+		t.Fatalf("CopyFromBase64() = %d, %v, %q", n, err, decoded.String())
+	}
+}
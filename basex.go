@@ -0,0 +1,78 @@
+package converter
+
+import "fmt"
+
+// encodeBaseX encodes the bytes as a big-endian number in the base given by the alphabet length, keeping each
+// leading zero byte as the first alphabet character, as done by base58 in Bitcoin addresses.
+func encodeBaseX(bs []byte, alphabet string) string {
+	base := len(alphabet)
+
+	zeros := 0
+	for zeros < len(bs) && bs[zeros] == 0 {
+		zeros++
+	}
+
+	digits := make([]byte, 0, len(bs)*138/100+1)
+	for _, b := range bs[zeros:] {
+		carry := int(b)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % base)
+			carry /= base
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%base))
+			carry /= base
+		}
+	}
+
+	out := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		out[i] = alphabet[0]
+	}
+	for i, digit := range digits {
+		out[len(out)-1-i] = alphabet[digit]
+	}
+	return string(out)
+}
+
+// decodeBaseX reverses encodeBaseX.
+func decodeBaseX(s string, alphabet string, name string) ([]byte, error) {
+	base := len(alphabet)
+
+	var index [256]int
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		index[alphabet[i]] = i
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	bs := make([]byte, 0, len(s))
+	for i := zeros; i < len(s); i++ {
+		carry := index[s[i]]
+		if carry < 0 {
+			return nil, fmt.Errorf("illegal %s data at input byte %d", name, i)
+		}
+		for j := range bs {
+			carry += int(bs[j]) * base
+			bs[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			bs = append(bs, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	out := make([]byte, zeros+len(bs))
+	for i, b := range bs {
+		out[len(out)-1-i] = b
+	}
+	return out, nil
+}
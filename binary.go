@@ -0,0 +1,405 @@
+package converter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ToBinary converts the given value to its fixed width binary representation, panicking if the conversion fails.
+// See ToBinaryWithErr.
+func ToBinary(a any, order binary.ByteOrder, width int) []byte {
+	bs, err := ToBinaryWithErr(a, order, width)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToBinaryWithErr converts the given value to its binary representation of the given width in bytes, using the
+// given byte order.
+//
+// Floats are encoded as IEEE 754, with a width of 4 for a float32 and 8 for a float64. Unsigned integers are
+// encoded as unsigned and any other value is converted with ToInt64WithErr and encoded as two's complement, with a
+// width of 1, 2, 4 or 8. An error is returned if the value does not fit the width.
+//
+// Parameters:
+//   - a: The value of any type to be converted to binary.
+//   - order: The byte order, e.g. binary.BigEndian.
+//   - width: The number of bytes of the result.
+//
+// Returns:
+//   - []byte: The binary representation of the provided value.
+//   - error: An error is returned in case of failure to convert or if the value does not fit the width.
+//
+// Example:
+//
+//	bs, _ := ToBinaryWithErr(1234, binary.BigEndian, 4)
+//	fmt.Println(bs) // [0 0 4 210]
+//
+//	bs, _ = ToBinaryWithErr(1.5, binary.LittleEndian, 8)
+//	fmt.Println(bs) // [0 0 0 0 0 0 248 63]
+//
+//	_, err := ToBinaryWithErr(300, binary.BigEndian, 1)
+//	fmt.Println(err) // error convert to binary, value 300 overflows 1 bytes
+func ToBinaryWithErr(a any, order binary.ByteOrder, width int) ([]byte, error) {
+	if order == nil {
+		return nil, errors.New("error convert to binary, byte order is nil")
+	} else if width != 1 && width != 2 && width != 4 && width != 8 {
+		return nil, fmt.Errorf("error convert to binary, unsupported width %d", width)
+	}
+
+	var u uint64
+	reflectValue := reflect.ValueOf(indirectValue(a))
+	switch reflectValue.Kind() {
+	case reflect.Float32, reflect.Float64:
+		switch width {
+		case 4:
+			u = uint64(math.Float32bits(float32(reflectValue.Float())))
+		case 8:
+			u = math.Float64bits(reflectValue.Float())
+		default:
+			return nil, fmt.Errorf("error convert to binary, unsupported width %d for float", width)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u = reflectValue.Uint()
+		if width < 8 && u>>(8*width) != 0 {
+			return nil, fmt.Errorf("error convert to binary, value %d overflows %d bytes", u, width)
+		}
+	default:
+		i, err := ToInt64WithErr(a)
+		if err != nil {
+			return nil, err
+		}
+		bits := 8 * width
+		if bits < 64 && (i < -1<<(bits-1) || i >= 1<<(bits-1)) {
+			return nil, fmt.Errorf("error convert to binary, value %d overflows %d bytes", i, width)
+		}
+		u = uint64(i)
+	}
+
+	bs := make([]byte, width)
+	putBinaryUint(bs, order, u)
+	return bs, nil
+}
+
+// FromBinary decodes a fixed width binary number into dest, panicking if decoding fails. See FromBinaryWithErr.
+func FromBinary(a any, order binary.ByteOrder, dest any) {
+	if err := FromBinaryWithErr(a, order, dest); err != nil {
+		panic(err)
+	}
+}
+
+// FromBinaryWithErr decodes a fixed width binary number, in the given byte order, into dest. The value is first
+// converted to a byte slice using ToBytesWithErr.
+//
+// The destination must be a non-nil pointer to an integer or float type and the number of bytes must match its size,
+// e.g. 4 for an *int32 or a *float32 and 8 for an *int or a *float64 on 64-bit platforms.
+//
+// Parameters:
+//   - a: The value holding the binary representation.
+//   - order: The byte order, e.g. binary.BigEndian.
+//   - dest: A pointer to the numeric destination.
+//
+// Returns:
+//   - error: An error is returned if the destination is not supported or the number of bytes does not match it.
+//
+// Example:
+//
+//	var i int32
+//	err := FromBinaryWithErr([]byte{0x00, 0x00, 0x04, 0xd2}, binary.BigEndian, &i)
+//	fmt.Println(i, err) // 1234 <nil>
+func FromBinaryWithErr(a any, order binary.ByteOrder, dest any) error {
+	if order == nil {
+		return errors.New("error convert from binary, byte order is nil")
+	}
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer || reflectDest.IsNil() {
+		return errors.New("error convert from binary, dest must be a non-nil pointer")
+	}
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return err
+	}
+
+	elem := reflectDest.Elem()
+	switch elem.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64:
+	default:
+		return fmt.Errorf("error convert from binary, unsupported dest type %s", elem.Type().String())
+	}
+	if size := int(elem.Type().Size()); len(bs) != size {
+		return fmt.Errorf("error convert from binary, expected %d bytes for %s, got %d", size, elem.Type().String(),
+			len(bs))
+	}
+
+	u := binaryUint(bs, order)
+	switch elem.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		elem.SetInt(signExtendBinary(u, len(bs)))
+	case reflect.Float32:
+		elem.SetFloat(float64(math.Float32frombits(uint32(u))))
+	case reflect.Float64:
+		elem.SetFloat(math.Float64frombits(u))
+	default:
+		elem.SetUint(u)
+	}
+	return nil
+}
+
+// FromBinaryInt64 decodes a binary signed integer of any supported width, panicking if decoding fails.
+// See FromBinaryInt64WithErr.
+func FromBinaryInt64(a any, order binary.ByteOrder) int64 {
+	i, err := FromBinaryInt64WithErr(a, order)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// FromBinaryInt64WithErr decodes a two's complement integer of 1, 2, 4 or 8 bytes, in the given byte order. The
+// value is first converted to a byte slice using ToBytesWithErr. Unlike ToInt64WithErr, which always parses a
+// []byte as decimal text, the bytes are read as the binary representation of the number.
+//
+// Example:
+//
+//	i, _ := FromBinaryInt64WithErr([]byte{0xff, 0xfe}, binary.BigEndian)
+//	fmt.Println(i) // -2
+func FromBinaryInt64WithErr(a any, order binary.ByteOrder) (int64, error) {
+	bs, err := binaryBytes(a, order)
+	if err != nil {
+		return 0, err
+	}
+	return intFromBinary(bs, order)
+}
+
+// FromBinaryUint64 decodes a binary unsigned integer of any supported width, panicking if decoding fails.
+// See FromBinaryUint64WithErr.
+func FromBinaryUint64(a any, order binary.ByteOrder) uint64 {
+	u, err := FromBinaryUint64WithErr(a, order)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// FromBinaryUint64WithErr decodes an unsigned integer of 1, 2, 4 or 8 bytes, in the given byte order, following
+// the rules of FromBinaryInt64WithErr.
+//
+// Example:
+//
+//	u, _ := FromBinaryUint64WithErr([]byte{0xff, 0xfe}, binary.BigEndian)
+//	fmt.Println(u) // 65534
+func FromBinaryUint64WithErr(a any, order binary.ByteOrder) (uint64, error) {
+	bs, err := binaryBytes(a, order)
+	if err != nil {
+		return 0, err
+	}
+	return uintFromBinary(bs, order)
+}
+
+// FromBinaryFloat64 decodes a binary float of any supported width, panicking if decoding fails.
+// See FromBinaryFloat64WithErr.
+func FromBinaryFloat64(a any, order binary.ByteOrder) float64 {
+	f, err := FromBinaryFloat64WithErr(a, order)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// FromBinaryFloat64WithErr decodes an IEEE 754 float in the given byte order, reading 4 bytes as a float32 and 8
+// bytes as a float64, following the rules of FromBinaryInt64WithErr.
+//
+// Example:
+//
+//	f, _ := FromBinaryFloat64WithErr([]byte{0x3f, 0xc0, 0x00, 0x00}, binary.BigEndian)
+//	fmt.Println(f) // 1.5
+func FromBinaryFloat64WithErr(a any, order binary.ByteOrder) (float64, error) {
+	bs, err := binaryBytes(a, order)
+	if err != nil {
+		return 0, err
+	}
+	return floatFromBinary(bs, order)
+}
+
+// ToUvarint converts the given value to an unsigned varint, panicking if the conversion fails.
+// See ToUvarintWithErr.
+func ToUvarint(a any) []byte {
+	bs, err := ToUvarintWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToUvarintWithErr converts the given value to an uint64 using ToUint64WithErr and encodes it as an unsigned
+// varint, as used by Protocol Buffers, where small numbers take fewer bytes.
+//
+// Example:
+//
+//	bs, _ := ToUvarintWithErr(300)
+//	fmt.Println(bs) // [172 2]
+func ToUvarintWithErr(a any) ([]byte, error) {
+	u, err := ToUint64WithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return binary.AppendUvarint(nil, u), nil
+}
+
+// ToVarint converts the given value to a zig-zag varint, panicking if the conversion fails. See ToVarintWithErr.
+func ToVarint(a any) []byte {
+	bs, err := ToVarintWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToVarintWithErr converts the given value to an int64 using ToInt64WithErr and encodes it as a zig-zag varint, so
+// that numbers of small magnitude take fewer bytes, negative ones included.
+//
+// Example:
+//
+//	bs, _ := ToVarintWithErr(-2)
+//	fmt.Println(bs) // [3]
+func ToVarintWithErr(a any) ([]byte, error) {
+	i, err := ToInt64WithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return binary.AppendVarint(nil, i), nil
+}
+
+// FromUvarint decodes an unsigned varint, panicking if decoding fails. See FromUvarintWithErr.
+func FromUvarint(a any) uint64 {
+	u, err := FromUvarintWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// FromUvarintWithErr decodes an unsigned varint. The value is first converted to a byte slice using ToBytesWithErr
+// and must hold exactly one varint.
+//
+// Example:
+//
+//	u, _ := FromUvarintWithErr([]byte{172, 2})
+//	fmt.Println(u) // 300
+func FromUvarintWithErr(a any) (uint64, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return 0, err
+	}
+	u, n := binary.Uvarint(bs)
+	return u, checkVarintLength(n, len(bs))
+}
+
+// FromVarint decodes a zig-zag varint, panicking if decoding fails. See FromVarintWithErr.
+func FromVarint(a any) int64 {
+	i, err := FromVarintWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// FromVarintWithErr decodes a zig-zag varint. The value is first converted to a byte slice using ToBytesWithErr
+// and must hold exactly one varint.
+//
+// Example:
+//
+//	i, _ := FromVarintWithErr([]byte{3})
+//	fmt.Println(i) // -2
+func FromVarintWithErr(a any) (int64, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return 0, err
+	}
+	i, n := binary.Varint(bs)
+	return i, checkVarintLength(n, len(bs))
+}
+
+func checkVarintLength(n, length int) error {
+	switch {
+	case n == 0:
+		return errors.New("error convert from varint, incomplete value")
+	case n < 0:
+		return errors.New("error convert from varint, value overflows 64 bits")
+	case n != length:
+		return fmt.Errorf("error convert from varint, %d trailing bytes", length-n)
+	}
+	return nil
+}
+
+func binaryBytes(a any, order binary.ByteOrder) ([]byte, error) {
+	if order == nil {
+		return nil, errors.New("error convert from binary, byte order is nil")
+	}
+	return ToBytesWithErr(a)
+}
+
+func intFromBinary(bs []byte, order binary.ByteOrder) (int64, error) {
+	if !isBinaryIntWidth(len(bs)) {
+		return 0, fmt.Errorf("error convert to int, unsupported binary width %d", len(bs))
+	}
+	return signExtendBinary(binaryUint(bs, order), len(bs)), nil
+}
+
+func uintFromBinary(bs []byte, order binary.ByteOrder) (uint64, error) {
+	if !isBinaryIntWidth(len(bs)) {
+		return 0, fmt.Errorf("error convert to uint, unsupported binary width %d", len(bs))
+	}
+	return binaryUint(bs, order), nil
+}
+
+func floatFromBinary(bs []byte, order binary.ByteOrder) (float64, error) {
+	switch len(bs) {
+	case 4:
+		return float64(math.Float32frombits(order.Uint32(bs))), nil
+	case 8:
+		return math.Float64frombits(order.Uint64(bs)), nil
+	default:
+		return 0, fmt.Errorf("error convert to float, unsupported binary width %d", len(bs))
+	}
+}
+
+func isBinaryIntWidth(width int) bool {
+	return width == 1 || width == 2 || width == 4 || width == 8
+}
+
+func binaryUint(bs []byte, order binary.ByteOrder) uint64 {
+	switch len(bs) {
+	case 1:
+		return uint64(bs[0])
+	case 2:
+		return uint64(order.Uint16(bs))
+	case 4:
+		return uint64(order.Uint32(bs))
+	default:
+		return order.Uint64(bs)
+	}
+}
+
+func putBinaryUint(bs []byte, order binary.ByteOrder, u uint64) {
+	switch len(bs) {
+	case 1:
+		bs[0] = byte(u)
+	case 2:
+		order.PutUint16(bs, uint16(u))
+	case 4:
+		order.PutUint32(bs, uint32(u))
+	default:
+		order.PutUint64(bs, u)
+	}
+}
+
+func signExtendBinary(u uint64, width int) int64 {
+	shift := 64 - 8*width
+	return int64(u<<shift) >> shift
+}
@@ -0,0 +1,178 @@
+package converter
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestToBinaryWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		order   binary.ByteOrder
+		width   int
+		want    []byte
+		wantErr bool
+	}{
+		{"Int big endian", 1234, binary.BigEndian, 4, []byte{0x00, 0x00, 0x04, 0xd2}, false},
+		{"Int little endian", 1234, binary.LittleEndian, 4, []byte{0xd2, 0x04, 0x00, 0x00}, false},
+		{"Negative int", -2, binary.BigEndian, 2, []byte{0xff, 0xfe}, false},
+		{"Uint8 max", uint8(255), binary.BigEndian, 1, []byte{0xff}, false},
+		{"Uint64", uint64(1), binary.BigEndian, 8, []byte{0, 0, 0, 0, 0, 0, 0, 1}, false},
+		{"String", "258", binary.BigEndian, 2, []byte{0x01, 0x02}, false},
+		{"Pointer", ToPointer(int16(7)), binary.LittleEndian, 2, []byte{0x07, 0x00}, false},
+		{"Float64", 1.5, binary.LittleEndian, 8, []byte{0, 0, 0, 0, 0, 0, 0xf8, 0x3f}, false},
+		{"Float32", float32(1.5), binary.BigEndian, 4, []byte{0x3f, 0xc0, 0x00, 0x00}, false},
+		{"Int overflow", 300, binary.BigEndian, 1, nil, true},
+		{"Int underflow", -129, binary.BigEndian, 1, nil, true},
+		{"Uint overflow", uint(65536), binary.BigEndian, 2, nil, true},
+		{"Float width", 1.5, binary.BigEndian, 2, nil, true},
+		{"Invalid width", 1, binary.BigEndian, 3, nil, true},
+		{"Nil order", 1, nil, 4, nil, true},
+		{"Invalid value", "abc", binary.BigEndian, 4, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBinaryWithErr(tc.input, tc.order, tc.width)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToBinaryWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToBinaryWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromBinaryWithErr(t *testing.T) {
+	var i32 int32
+	if err := FromBinaryWithErr([]byte{0x00, 0x00, 0x04, 0xd2}, binary.BigEndian, &i32); err != nil || i32 != 1234 {
+		t.Errorf("FromBinaryWithErr() int32 = %v, %v", i32, err)
+	}
+	var i16 int16
+	if err := FromBinaryWithErr([]byte{0xfe, 0xff}, binary.LittleEndian, &i16); err != nil || i16 != -2 {
+		t.Errorf("FromBinaryWithErr() int16 = %v, %v", i16, err)
+	}
+	var u8 uint8
+	if err := FromBinaryWithErr([]byte{0xff}, binary.BigEndian, &u8); err != nil || u8 != 255 {
+		t.Errorf("FromBinaryWithErr() uint8 = %v, %v", u8, err)
+	}
+	var f32 float32
+	if err := FromBinaryWithErr([]byte{0x3f, 0xc0, 0x00, 0x00}, binary.BigEndian, &f32); err != nil || f32 != 1.5 {
+		t.Errorf("FromBinaryWithErr() float32 = %v, %v", f32, err)
+	}
+	var f64 float64
+	bs := ToBinary(-3.25, binary.BigEndian, 8)
+	if err := FromBinaryWithErr(bs, binary.BigEndian, &f64); err != nil || f64 != -3.25 {
+		t.Errorf("FromBinaryWithErr() float64 = %v, %v", f64, err)
+	}
+
+	if err := FromBinaryWithErr([]byte{0x01, 0x02}, binary.BigEndian, &i32); err == nil {
+		t.Error("FromBinaryWithErr() expected error for wrong length")
+	}
+	var s string
+	if err := FromBinaryWithErr([]byte{0x01}, binary.BigEndian, &s); err == nil {
+		t.Error("FromBinaryWithErr() expected error for unsupported dest")
+	}
+	if err := FromBinaryWithErr([]byte{0x01}, binary.BigEndian, u8); err == nil {
+		t.Error("FromBinaryWithErr() expected error for non-pointer dest")
+	}
+}
+
+func TestVarint(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input int64
+		want  []byte
+	}{
+		{"Zero", 0, []byte{0}},
+		{"Minus one", -1, []byte{1}},
+		{"Minus two", -2, []byte{3}},
+		{"Large", 300, []byte{0xd8, 0x04}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ToVarint(tc.input)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToVarint() = %v, want %v", got, tc.want)
+			}
+			if back := FromVarint(got); back != tc.input {
+				t.Errorf("FromVarint() = %v, want %v", back, tc.input)
+			}
+		})
+	}
+}
+
+func TestUvarint(t *testing.T) {
+	got := ToUvarint(300)
+	if !reflect.DeepEqual(got, []byte{0xac, 0x02}) {
+		t.Errorf("ToUvarint() = %v", got)
+	}
+	if u := FromUvarint(got); u != 300 {
+		t.Errorf("FromUvarint() = %v, want 300", u)
+	}
+	if _, err := ToUvarintWithErr(-1); err == nil {
+		t.Error("ToUvarintWithErr() expected error for negative value")
+	}
+	if _, err := FromUvarintWithErr([]byte{0xac}); err == nil {
+		t.Error("FromUvarintWithErr() expected error for incomplete value")
+	}
+	if _, err := FromUvarintWithErr([]byte{0xac, 0x02, 0x01}); err == nil {
+		t.Error("FromUvarintWithErr() expected error for trailing bytes")
+	}
+}
+
+func TestBinaryBytesAsText(t *testing.T) {
+	var i int16
+	FromBinary([]byte{0x00, 0x2a}, binary.BigEndian, &i)
+	if i != 42 {
+		t.Errorf("FromBinary() = %v, want 42", i)
+	}
+
+	if got, err := ToIntWithErr([]byte("42")); err != nil || got != 42 {
+		t.Errorf("ToIntWithErr() = %v, %v, want 42 parsed as text", got, err)
+	}
+	if got, err := ToUintWithErr([]byte("42")); err != nil || got != 42 {
+		t.Errorf("ToUintWithErr() = %v, %v, want 42 parsed as text", got, err)
+	}
+	if got, err := ToFloat64WithErr([]byte("1.5")); err != nil || got != 1.5 {
+		t.Errorf("ToFloat64WithErr() = %v, %v, want 1.5 parsed as text", got, err)
+	}
+	if _, err := ToIntWithErr([]byte{0x00, 0x2a}); err == nil {
+		t.Error("ToIntWithErr() expected error for binary bytes")
+	}
+}
+
+func TestFromBinaryNumbers(t *testing.T) {
+	if i := FromBinaryInt64([]byte{0x00, 0x00, 0x04, 0xd2}, binary.BigEndian); i != 1234 {
+		t.Errorf("FromBinaryInt64() = %v, want 1234", i)
+	}
+	if i := FromBinaryInt64([]byte{0xff, 0xfe}, binary.BigEndian); i != -2 {
+		t.Errorf("FromBinaryInt64() = %v, want -2", i)
+	}
+	if i := FromBinaryInt64([]byte{0xd2, 0x04}, binary.LittleEndian); i != 1234 {
+		t.Errorf("FromBinaryInt64() little endian = %v, want 1234", i)
+	}
+	if u := FromBinaryUint64([]byte{0xff, 0xfe}, binary.BigEndian); u != 65534 {
+		t.Errorf("FromBinaryUint64() = %v, want 65534", u)
+	}
+	if f := FromBinaryFloat64([]byte{0x3f, 0xc0, 0x00, 0x00}, binary.BigEndian); f != 1.5 {
+		t.Errorf("FromBinaryFloat64() = %v, want 1.5", f)
+	}
+	if f := FromBinaryFloat64(ToBinary(1.25, binary.LittleEndian, 8), binary.LittleEndian); f != 1.25 {
+		t.Errorf("FromBinaryFloat64() = %v, want 1.25", f)
+	}
+
+	if _, err := FromBinaryInt64WithErr([]byte{1, 2, 3}, binary.BigEndian); err == nil {
+		t.Error("FromBinaryInt64WithErr() expected error for unsupported width")
+	}
+	if _, err := FromBinaryUint64WithErr([]byte{1, 2}, nil); err == nil {
+		t.Error("FromBinaryUint64WithErr() expected error for nil byte order")
+	}
+	if _, err := FromBinaryFloat64WithErr([]byte{1, 2}, binary.BigEndian); err == nil {
+		t.Error("FromBinaryFloat64WithErr() expected error for unsupported width")
+	}
+}
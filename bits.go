@@ -0,0 +1,201 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strings"
+)
+
+// ToBitString converts the given value to a string of binary digits, panicking if the conversion fails.
+// See ToBitStringWithErr.
+func ToBitString(a any, width int) string {
+	s, err := ToBitStringWithErr(a, width)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToBitStringWithErr converts the given value to an integer and renders it as a string of binary digits, most
+// significant bit first, left padded with zeros to the given width.
+//
+// Signed integers are converted with ToInt64WithErr and negative ones are rendered as two's complement of the
+// width. Any other value is converted with ToUint64WithErr. A width of 0 uses the fewest digits needed, or 64 for
+// negative values. An error is returned if the value does not fit the width.
+//
+// Parameters:
+//   - a: The value of any type to be rendered in binary.
+//   - width: The number of digits of the result, from 0 to 64.
+//
+// Returns:
+//   - string: The binary digits of the provided value.
+//   - error: An error is returned in case of failure to convert or if the value does not fit the width.
+//
+// Example:
+//
+//	s, _ := ToBitStringWithErr(5, 8)
+//	fmt.Println(s) // 00000101
+//
+//	s, _ = ToBitStringWithErr(int8(-2), 8)
+//	fmt.Println(s) // 11111110
+func ToBitStringWithErr(a any, width int) (string, error) {
+	u, width, err := bitsOf(a, width)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, width)
+	for i := range buf {
+		buf[width-1-i] = '0' + byte(u>>i&1)
+	}
+	return string(buf), nil
+}
+
+// FromBitString parses a string of binary digits, panicking if parsing fails. See FromBitStringWithErr.
+func FromBitString(a any) uint64 {
+	u, err := FromBitStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// FromBitStringWithErr parses a string of binary digits, most significant bit first, into an uint64. The value is
+// first converted to a string using ToStringWithErr. An optional "0b" prefix is accepted, and underscores and
+// spaces may be used to group the digits.
+//
+// Example:
+//
+//	u, _ := FromBitStringWithErr("0b1000_0101")
+//	fmt.Println(u) // 133
+func FromBitStringWithErr(a any) (uint64, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return 0, err
+	}
+
+	text := strings.TrimSpace(s)
+	if len(text) > 2 && (text[:2] == "0b" || text[:2] == "0B") {
+		text = text[2:]
+	}
+
+	var u uint64
+	digits := 0
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '_', ' ':
+			continue
+		case '0', '1':
+			if digits == 64 {
+				return 0, fmt.Errorf("error convert from bit string, %q overflows 64 bits", s)
+			}
+			u = u<<1 | uint64(c-'0')
+			digits++
+		default:
+			return 0, fmt.Errorf("error convert from bit string, invalid digit %q in %q", c, s)
+		}
+	}
+	if digits == 0 {
+		return 0, errors.New("error convert from bit string, it is empty")
+	}
+	return u, nil
+}
+
+// ToBits converts the given value to a slice of bits, panicking if the conversion fails. See ToBitsWithErr.
+func ToBits(a any, width int) []bool {
+	bs, err := ToBitsWithErr(a, width)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToBitsWithErr converts the given value to a slice of bits, where the element at index i holds bit i, so index 0
+// is the least significant bit. The value and width follow the same rules as ToBitStringWithErr.
+//
+// Example:
+//
+//	bs, _ := ToBitsWithErr(5, 4)
+//	fmt.Println(bs) // [true false true false]
+func ToBitsWithErr(a any, width int) ([]bool, error) {
+	u, width, err := bitsOf(a, width)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, width)
+	for i := range result {
+		result[i] = u>>i&1 == 1
+	}
+	return result, nil
+}
+
+// FromBits converts a slice of bits to an uint64, panicking if it has more than 64 elements. See FromBitsWithErr.
+func FromBits(bs []bool) uint64 {
+	u, err := FromBitsWithErr(bs)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// FromBitsWithErr converts a slice of bits, where the element at index i holds bit i, to an uint64. It is the
+// reverse of ToBitsWithErr.
+//
+// Example:
+//
+//	u, _ := FromBitsWithErr([]bool{true, false, true})
+//	fmt.Println(u) // 5
+func FromBitsWithErr(bs []bool) (uint64, error) {
+	if len(bs) > 64 {
+		return 0, fmt.Errorf("error convert from bits, %d bits overflow 64 bits", len(bs))
+	}
+
+	var u uint64
+	for i, bit := range bs {
+		if bit {
+			u |= 1 << i
+		}
+	}
+	return u, nil
+}
+
+func bitsOf(a any, width int) (uint64, int, error) {
+	if width < 0 || width > 64 {
+		return 0, 0, fmt.Errorf("error convert to bits, unsupported width %d", width)
+	}
+
+	switch reflect.ValueOf(indirectValue(a)).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ToInt64WithErr(a)
+		if err != nil {
+			return 0, 0, err
+		} else if i < 0 {
+			if width == 0 {
+				width = 64
+			} else if width < 64 && i < -1<<(width-1) {
+				return 0, 0, fmt.Errorf("error convert to bits, value %d overflows %d bits", i, width)
+			}
+			return uint64(i) & (1<<width - 1), width, nil
+		}
+		return fitBits(uint64(i), width)
+	default:
+		u, err := ToUint64WithErr(a)
+		if err != nil {
+			return 0, 0, err
+		}
+		return fitBits(u, width)
+	}
+}
+
+func fitBits(u uint64, width int) (uint64, int, error) {
+	length := max(bits.Len64(u), 1)
+	if width == 0 {
+		width = length
+	} else if length > width {
+		return 0, 0, fmt.Errorf("error convert to bits, value %d overflows %d bits", u, width)
+	}
+	return u, width, nil
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToBitStringWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		width   int
+		want    string
+		wantErr bool
+	}{
+		{"Padded", 5, 8, "00000101", false},
+		{"Minimal width", 5, 0, "101", false},
+		{"Zero", 0, 0, "0", false},
+		{"Negative int8", int8(-2), 8, "11111110", false},
+		{"Negative minimal width", -1, 0, "1111111111111111111111111111111111111111111111111111111111111111", false},
+		{"Uint64 max", ^uint64(0), 64, "1111111111111111111111111111111111111111111111111111111111111111", false},
+		{"String", "10", 4, "1010", false},
+		{"Overflow", 16, 4, "", true},
+		{"Negative overflow", -9, 4, "", true},
+		{"Invalid width", 1, 65, "", true},
+		{"Invalid value", "abc", 8, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBitStringWithErr(tc.input, tc.width)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToBitStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToBitStringWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromBitStringWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    uint64
+		wantErr bool
+	}{
+		{"Plain", "101", 5, false},
+		{"Prefix and groups", "0b1000_0101", 133, false},
+		{"Spaces", "1111 0000", 240, false},
+		{"Bytes", []byte("11"), 3, false},
+		{"Invalid digit", "102", 0, true},
+		{"Empty", "0b", 0, true},
+		{"Overflow", "1" + ToBitString(0, 64), 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBitStringWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("FromBitStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("FromBitStringWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBits(t *testing.T) {
+	got := ToBits(5, 4)
+	if want := []bool{true, false, true, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToBits() = %v, want %v", got, want)
+	}
+	if u := FromBits(got); u != 5 {
+		t.Errorf("FromBits() = %v, want 5", u)
+	}
+	if got = ToBits(int16(-1), 0); len(got) != 64 || !got[63] {
+		t.Errorf("ToBits() negative = %v", got)
+	}
+	if _, err := ToBitsWithErr(256, 8); err == nil {
+		t.Error("ToBitsWithErr() expected overflow error")
+	}
+	if _, err := FromBitsWithErr(make([]bool, 65)); err == nil {
+		t.Error("FromBitsWithErr() expected overflow error")
+	}
+}
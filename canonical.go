@@ -0,0 +1,217 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+)
+
+// ToCanonicalJSON converts the given value to canonical JSON, panicking if the conversion fails.
+// See ToCanonicalJSONWithErr.
+func ToCanonicalJSON(a any) string {
+	s, err := ToCanonicalJSONWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToCanonicalJSONWithErr converts the given value to JSON following the JSON Canonicalization Scheme (RFC 8785),
+// so that equal documents always produce the same bytes, whatever service or language produced them.
+//
+// The value is first converted to a byte slice using ToBytesWithErr, so structs, maps and slices are marshaled
+// to JSON, and strings and byte slices holding JSON are canonicalized as the document they hold. Other text, such
+// as plain strings and times, becomes a JSON string, and nil becomes null. JSON5 documents can be converted with
+// ToStrictJSONWithErr first.
+//
+// The output has no whitespace, object members are sorted by the UTF-16 code units of their names, strings
+// escape only quotes, backslashes and control characters, and numbers are written as the shortest form that
+// reads back to the same IEEE 754 double, like JavaScript does, e.g. 1e+21, 1e-7 and 4.5. Numbers that do not
+// fit in a double, such as integers above 2^53, lose precision, and those out of its range are an error.
+//
+// Parameters:
+//   - a: The value of any type to be converted to canonical JSON.
+//
+// Returns:
+//   - string: The canonical JSON representation of the provided value.
+//   - error: An error is returned in case of failure to convert or if a number is out of range.
+//
+// Example:
+//
+//	s, _ := ToCanonicalJSONWithErr(`{"b": [1.0, 1E2, 0.000001], "a": "é\u000f", "€": 1, "😀": 2}`)
+//	fmt.Println(s) // {"a":"é\u000f","b":[1,100,0.000001],"€":1,"😀":2}
+func ToCanonicalJSONWithErr(a any) (string, error) {
+	bs, err := canonicalJSON(a)
+	return string(bs), err
+}
+
+// Fingerprint returns the hexadecimal digest of the canonical JSON of the given value, panicking if the conversion
+// fails. See FingerprintWithErr.
+func Fingerprint(a any, h func() hash.Hash) string {
+	s, err := FingerprintWithErr(a, h)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FingerprintWithErr hashes the canonical JSON of the given value, produced by ToCanonicalJSONWithErr, and
+// returns the digest as a lower case hexadecimal string. Since the canonical form does not depend on key order,
+// whitespace or number formatting, equal documents have the same fingerprint, which makes it suitable for
+// idempotency keys, caches and signature payloads.
+//
+// Parameters:
+//   - a: The value of any type to be fingerprinted.
+//   - h: The hash constructor, e.g. sha256.New.
+//
+// Returns:
+//   - string: The hexadecimal digest.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	x, _ := FingerprintWithErr(map[string]any{"id": 1, "amount": 10.50}, sha256.New)
+//	y, _ := FingerprintWithErr(`{ "amount": 10.5, "id": 1.0 }`, sha256.New)
+//	fmt.Println(x == y) // true
+func FingerprintWithErr(a any, h func() hash.Hash) (string, error) {
+	digest, err := canonicalDigest(a, h)
+	if err != nil {
+		return "", err
+	}
+	return encodeHex(digest), nil
+}
+
+// FingerprintBase64 returns the base64 digest of the canonical JSON of the given value, panicking if the
+// conversion fails. See FingerprintBase64WithErr.
+func FingerprintBase64(a any, h func() hash.Hash, variant Base64Variant) string {
+	s, err := FingerprintBase64WithErr(a, h, variant)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FingerprintBase64WithErr is like FingerprintWithErr, but returns the digest as a base64 string in the given
+// variant, e.g. Base64RawURL for a short key that is safe in URLs.
+//
+// Example:
+//
+//	s, _ := FingerprintBase64WithErr(order, sha256.New, Base64RawURL)
+//	fmt.Println(len(s)) // 43
+func FingerprintBase64WithErr(a any, h func() hash.Hash, variant Base64Variant) (string, error) {
+	digest, err := canonicalDigest(a, h)
+	if err != nil {
+		return "", err
+	}
+	return ToBase64VariantWithErr(digest, variant)
+}
+
+func canonicalDigest(a any, h func() hash.Hash) ([]byte, error) {
+	bs, err := canonicalJSON(a)
+	if err != nil {
+		return nil, err
+	}
+	hasher := h()
+	hasher.Write(bs)
+	return hasher.Sum(nil), nil
+}
+
+func canonicalJSON(a any) ([]byte, error) {
+	bs := []byte("null")
+	if isNonNil(indirectValue(a)) {
+		var err error
+		if bs, err = ToBytesWithErr(a); err != nil {
+			return nil, err
+		}
+	}
+
+	if !json.Valid(bs) {
+		bs = appendJSONString(nil, string(bs))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("error convert to canonical JSON: %w", err)
+	}
+	return appendCanonicalJSON(nil, value)
+}
+
+func appendCanonicalJSON(bs []byte, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(bs, "null"...), nil
+	case bool:
+		return strconv.AppendBool(bs, v), nil
+	case string:
+		return appendJSONString(bs, v), nil
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("error convert to canonical JSON, number %s is out of range", v)
+		}
+		return appendCanonicalNumber(bs, f), nil
+	case []any:
+		bs = append(bs, '[')
+		for i, item := range v {
+			if i > 0 {
+				bs = append(bs, ',')
+			}
+			var err error
+			if bs, err = appendCanonicalJSON(bs, item); err != nil {
+				return nil, err
+			}
+		}
+		return append(bs, ']'), nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, func(x, y string) int {
+			return slices.Compare(utf16.Encode([]rune(x)), utf16.Encode([]rune(y)))
+		})
+
+		bs = append(bs, '{')
+		for i, key := range keys {
+			if i > 0 {
+				bs = append(bs, ',')
+			}
+			bs = append(appendJSONString(bs, key), ':')
+			var err error
+			if bs, err = appendCanonicalJSON(bs, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return append(bs, '}'), nil
+	default:
+		return nil, fmt.Errorf("error convert to canonical JSON, unexpected type %T", value)
+	}
+}
+
+// appendCanonicalNumber appends f as JavaScript's Number.prototype.toString does, which RFC 8785 requires.
+func appendCanonicalNumber(bs []byte, f float64) []byte {
+	if f == 0 {
+		return append(bs, '0')
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	start := len(bs)
+	bs = strconv.AppendFloat(bs, f, format, -1, 64)
+	if format == 'e' {
+		// Go writes at least two exponent digits, e.g. 1e-07, where JavaScript writes 1e-7.
+		if n := len(bs); n-start >= 4 && bs[n-4] == 'e' && bs[n-3] == '-' && bs[n-2] == '0' {
+			bs = append(bs[:n-2], bs[n-1])
+		}
+	}
+	return bs
+}
@@ -0,0 +1,126 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestToCanonicalJSONWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "RFC 8785 example",
+			input: `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/", "literals": [null, true, false]}`,
+			want: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],` +
+				`"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name:  "UTF-16 key order",
+			input: `{"€": 1, "\r": 2, "דּ": 3, "1": 4, "😀": 5, "\u0080": 6, "ö": 7}`,
+			want:  `{"\r":2,"1":4,"` + "\u0080" + `":6,"ö":7,"€":1,"😀":5,"דּ":3}`,
+		},
+		{
+			name: "Struct",
+			input: struct {
+				Name  string  `json:"name"`
+				Price float64 `json:"price"`
+				ID    int64   `json:"id"`
+			}{"a<b>", 10.50, 1},
+			want: `{"id":1,"name":"a<b>","price":10.5}`,
+		},
+		{name: "Map", input: map[string]any{"b": []int{}, "a": map[string]any{}}, want: `{"a":{},"b":[]}`},
+		{name: "Number", input: 100.0, want: "100"},
+		{name: "Plain string", input: "hello  world", want: `"hello  world"`},
+		{name: "Byte slice holding JSON", input: []byte(` [ 1 , "x" ] `), want: `[1,"x"]`},
+		{name: "JSON5 text", input: "{a: 1}", want: `"{a: 1}"`},
+		{name: "Nil", input: nil, want: "null"},
+		{name: "Nil pointer", input: (*int)(nil), want: "null"},
+		{name: "Out of range", input: "[1e400]", wantErr: true},
+		{name: "Unsupported", input: make(chan int), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToCanonicalJSONWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToCanonicalJSONWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToCanonicalJSONWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAppendCanonicalNumber(t *testing.T) {
+	// Test vectors from RFC 8785, appendix B.
+	testCases := []struct {
+		bits uint64
+		want string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+	}
+
+	for _, tc := range testCases {
+		bs := make([]byte, 8)
+		binary.BigEndian.PutUint64(bs, tc.bits)
+		if got := string(appendCanonicalNumber(nil, math.Float64frombits(tc.bits))); got != tc.want {
+			t.Errorf("appendCanonicalNumber(%x) = %v, want %v", bs, got, tc.want)
+		}
+	}
+}
+
+func TestFingerprintWithErr(t *testing.T) {
+	x, err := FingerprintWithErr(map[string]any{"id": 1, "amount": 10.50, "tags": []string{"a"}}, sha256.New)
+	if err != nil {
+		t.Fatalf("FingerprintWithErr() error = %v", err)
+	}
+	y := Fingerprint("{\n  \"tags\": [\"a\"],\n  \"amount\": 1.05e1,\n  \"id\": 1.0\n}", sha256.New)
+	if x != y {
+		t.Errorf("Fingerprint() = %v, want %v", y, x)
+	}
+	if want := ToHex(sha256.Sum256([]byte(`{"amount":10.5,"id":1,"tags":["a"]}`))); x != want {
+		t.Errorf("FingerprintWithErr() = %v, want %v", x, want)
+	}
+	if z := Fingerprint(map[string]any{"id": 2}, sha256.New); z == x {
+		t.Error("Fingerprint() want different digests for different documents")
+	}
+
+	s, err := FingerprintBase64WithErr(`{"id":1}`, sha256.New, Base64RawURL)
+	if err != nil || s != "A3ySFO73TMOIfzpPCFtOF9digNr9JzsO4WDAnEuhz9Q" {
+		t.Errorf("FingerprintBase64WithErr() = %v, %v", s, err)
+	}
+	if s = FingerprintBase64(`{"id":1}`, sha256.New, Base64Std); len(s) != 44 {
+		t.Errorf("FingerprintBase64() = %v, want a padded digest", s)
+	}
+
+	if _, err = FingerprintWithErr(make(chan int), sha256.New); err == nil {
+		t.Error("FingerprintWithErr() error = nil, want an error for an unsupported value")
+	}
+	if _, err = FingerprintBase64WithErr(1, sha256.New, Base64Variant(99)); err == nil {
+		t.Error("FingerprintBase64WithErr() error = nil, want an error for an unknown variant")
+	}
+}
@@ -0,0 +1,669 @@
+package converter
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// cborTags are the struct tags that name fields in CBOR maps, in order of precedence.
+var cborTags = []string{"cbor", "json"}
+
+// cborMaxDepth limits the nesting of decoded CBOR values, so that malicious input cannot exhaust the stack.
+const cborMaxDepth = 1000
+
+const (
+	cborUint byte = iota
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	cborTagTimeString = 0
+	cborTagTimeEpoch  = 1
+	cborTagPosBignum  = 2
+	cborTagNegBignum  = 3
+)
+
+// cborBreak ends the items of an indefinite-length value.
+const cborBreak = 0xff
+
+var (
+	bigIntType     = reflect.TypeOf(big.Int{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+)
+
+// CBOR is a CBOR document, as returned by ToCBOR. Wrapping bytes in it tells ToDestWithErr to decode them with
+// FromCBORWithErr, since CBOR cannot be told apart from text, while ToBytesWithErr returns them unchanged.
+//
+// Example:
+//
+//	var reading map[string]float64
+//	err := ToDestWithErr(CBOR(ToCBOR(map[string]any{"value": 21.5})), &reading)
+//	fmt.Println(reading, err) // map[value:21.5] <nil>
+type CBOR []byte
+
+// ToCBOR converts the given value to CBOR, panicking if the conversion fails. See ToCBORWithErr.
+func ToCBOR(a any) []byte {
+	bs, err := ToCBORWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToCBORWithErr converts the given value to CBOR (RFC 8949), with the shortest form of integers and lengths and
+// definite lengths only.
+//
+// Values are encoded as follows:
+//   - nil, nil pointers, nil maps and nil slices: null.
+//   - Booleans and strings: their CBOR type.
+//   - Integers: unsigned or negative integers.
+//   - big.Int: an integer when it fits in 64 bits, otherwise a bignum, tag 2 or 3.
+//   - Floats: a float of the same width.
+//   - Byte slices and arrays: byte strings.
+//   - time.Time: an RFC 3339 string with nanoseconds, tag 0.
+//   - Types implementing encoding.TextMarshaler, such as UUID and Date, and types registered with RegisterFlags:
+//     text strings, as rendered by MarshalText or ToStringWithErr.
+//   - Slices and arrays: arrays.
+//   - Maps: maps sorted by their encoded keys, so that the output is stable.
+//   - Structs: maps whose keys are the `cbor` tag, the `json` tag or the field name, in field order. Fields tagged
+//     with "-" are skipped, and so are zero fields with the omitempty option. Embedded structs have their fields
+//     promoted.
+//
+// Use ToDeterministicCBORWithErr when the bytes must be reproducible, e.g. to be signed.
+//
+// Parameters:
+//   - a: The value of any type to be converted to CBOR.
+//
+// Returns:
+//   - []byte: The CBOR representation of the provided value.
+//   - error: An error is returned in case of failure to convert, e.g. for channels or functions.
+//
+// Example:
+//
+//	bs, _ := ToCBORWithErr(map[string]any{"id": 1, "ok": true})
+//	fmt.Printf("%x", bs) // a262696401626f6bf5
+func ToCBORWithErr(a any) ([]byte, error) {
+	return cborEncoder{}.append(nil, reflect.ValueOf(a))
+}
+
+// ToDeterministicCBOR converts the given value to deterministic CBOR, panicking if the conversion fails.
+// See ToDeterministicCBORWithErr.
+func ToDeterministicCBOR(a any) []byte {
+	bs, err := ToDeterministicCBORWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToDeterministicCBORWithErr converts the given value to CBOR like ToCBORWithErr, following the core deterministic
+// encoding requirements of RFC 8949, section 4.2.1, so that equal values always produce the same bytes:
+//   - Integers, lengths and tags use their shortest form, and lengths are always definite.
+//   - Floats use the shortest of half, single and double precision that represents the value exactly, and NaN is
+//     encoded as the half precision 0xf97e00.
+//   - The keys of maps, including those of structs, are sorted by the bytewise lexicographic order of their
+//     encoding.
+//
+// Example:
+//
+//	bs, _ := ToDeterministicCBORWithErr(struct {
+//		Name  string  `cbor:"name"`
+//		Price float64 `cbor:"a"`
+//	}{"x", 1.5})
+//	fmt.Printf("%x", bs) // a26161f93e00646e616d656178
+func ToDeterministicCBORWithErr(a any) ([]byte, error) {
+	return cborEncoder{deterministic: true}.append(nil, reflect.ValueOf(a))
+}
+
+// FromCBOR decodes the given CBOR into dest, panicking if decoding fails. See FromCBORWithErr.
+func FromCBOR(a, dest any) {
+	if err := FromCBORWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// FromCBORWithErr converts the given value to a byte slice using ToBytesWithErr and decodes it as CBOR into dest,
+// with the weak typing of ToDestWithErr, so that a string "10" fills an int and an integer fills a string.
+//
+// Definite and indefinite lengths are accepted. Times, tags 0 and 1, fill time.Time and bignums, tags 2 and 3,
+// fill big.Int and integer destinations. Other tags are ignored and their content decoded. Struct fields are named
+// as in ToCBORWithErr and matched case insensitively when there is no exact match, and null and undefined leave
+// the destination unchanged. An interface destination, such as *any, receives a generic tree made of nil, bool,
+// int64, uint64 for integers above math.MaxInt64, *big.Int for integers outside both ranges, float64, string,
+// []byte, time.Time, []any and map[string]any.
+//
+// Example:
+//
+//	var event struct {
+//		ID int       `cbor:"id"`
+//		At time.Time `cbor:"at"`
+//	}
+//	err := FromCBORWithErr(ToCBOR(map[string]any{"id": 7, "at": time.Unix(0, 0)}), &event)
+//	fmt.Println(event.ID, event.At.Unix(), err) // 7 0 <nil>
+func FromCBORWithErr(a, dest any) error {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return err
+	}
+
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer {
+		return errors.New("dest is not a pointer")
+	} else if reflectDest.IsNil() {
+		return errors.New("dest is nil")
+	}
+
+	value, err := decodeCBOR(bs)
+	if err != nil {
+		return err
+	}
+	return weakDecoder{tags: cborTags}.decode(value, reflectDest.Elem())
+}
+
+// CBORStep returns a step that converts JSON to CBOR when encoding and CBOR back to compact JSON when decoding, so
+// that a Pipeline carries the values it encodes as CBOR. Data that is not JSON is carried as a CBOR byte string and
+// decoded as it was.
+//
+// Example:
+//
+//	pipeline := NewPipeline(CBORStep(), Base64Step(Base64RawURL))
+func CBORStep() PipelineStep {
+	return NewPipelineStep(encodeCBORStep, decodeCBORStep)
+}
+
+func encodeCBORStep(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return cborEncoder{}.appendString(nil, cborBytes, string(data)), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return ToCBORWithErr(value)
+}
+
+func decodeCBORStep(data []byte) ([]byte, error) {
+	value, err := decodeCBOR(data)
+	if err != nil {
+		return nil, err
+	} else if bs, ok := value.([]byte); ok {
+		return bs, nil
+	}
+	return json.Marshal(value)
+}
+
+type cborEncoder struct {
+	deterministic bool
+}
+
+func (e cborEncoder) append(bs []byte, value reflect.Value) ([]byte, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return append(bs, 0xf6), nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return append(bs, 0xf6), nil
+	}
+
+	if t, ok := timeIfPresent(value); ok {
+		bs = e.appendHead(bs, cborTag, cborTagTimeString)
+		return e.appendString(bs, cborText, t.Format(time.RFC3339Nano)), nil
+	} else if value.Type() == bigIntType {
+		x := reflect.New(bigIntType)
+		x.Elem().Set(value)
+		return e.appendBigInt(bs, x.Interface().(*big.Int)), nil
+	} else if value.Type() == jsonNumberType {
+		return e.appendJSONNumber(bs, json.Number(value.String()))
+	} else if _, ok := lookupFlagSet(value.Type()); ok || value.Type().Implements(textMarshalerType) {
+		var s string
+		var err error
+		if ok {
+			s, err = ToStringWithErr(value.Interface())
+		} else {
+			var text []byte
+			text, err = value.Interface().(encoding.TextMarshaler).MarshalText()
+			s = string(text)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return e.appendString(bs, cborText, s), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return append(bs, 0xf5), nil
+		}
+		return append(bs, 0xf4), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := value.Int(); i < 0 {
+			return e.appendHead(bs, cborNegInt, uint64(-1-i)), nil
+		}
+		return e.appendHead(bs, cborUint, uint64(value.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.appendHead(bs, cborUint, value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return e.appendFloat(bs, value.Float(), value.Type().Bits()), nil
+	case reflect.String:
+		return e.appendString(bs, cborText, value.String()), nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return append(bs, 0xf6), nil
+		} else if value.Type().Elem().Kind() == reflect.Uint8 {
+			s, err := ToStringWithErr(value.Interface())
+			if err != nil {
+				return nil, err
+			}
+			return e.appendString(bs, cborBytes, s), nil
+		}
+		bs = e.appendHead(bs, cborArray, uint64(value.Len()))
+		for i := 0; i < value.Len(); i++ {
+			var err error
+			if bs, err = e.append(bs, value.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return bs, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return append(bs, 0xf6), nil
+		}
+		entries := make([][2][]byte, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, err := e.append(nil, iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			elem, err := e.append(nil, iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, [2][]byte{key, elem})
+		}
+		return e.appendEntries(bs, entries, true), nil
+	case reflect.Struct:
+		var entries [][2][]byte
+		for _, field := range weakFields(value.Type(), cborTags) {
+			fieldValue := value.FieldByIndex(field.index)
+			if field.omitEmpty && fieldValue.IsZero() {
+				continue
+			}
+			elem, err := e.append(nil, fieldValue)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, [2][]byte{e.appendString(nil, cborText, field.name), elem})
+		}
+		return e.appendEntries(bs, entries, e.deterministic), nil
+	default:
+		return nil, fmt.Errorf("error convert to CBOR, unsupported type %s", value.Kind().String())
+	}
+}
+
+func (e cborEncoder) appendHead(bs []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(bs, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(bs, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(bs, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(bs, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(bs, major|27), n)
+	}
+}
+
+func (e cborEncoder) appendString(bs []byte, major byte, s string) []byte {
+	return append(e.appendHead(bs, major, uint64(len(s))), s...)
+}
+
+// appendEntries appends a map made of the given encoded keys and values, sorted by the bytewise lexicographic order
+// of the keys when sorted is true.
+func (e cborEncoder) appendEntries(bs []byte, entries [][2][]byte, sorted bool) []byte {
+	if sorted {
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i][0], entries[j][0]) < 0 })
+	}
+	bs = e.appendHead(bs, cborMap, uint64(len(entries)))
+	for _, entry := range entries {
+		bs = append(append(bs, entry[0]...), entry[1]...)
+	}
+	return bs
+}
+
+func (e cborEncoder) appendFloat(bs []byte, f float64, bits int) []byte {
+	if e.deterministic {
+		if math.IsNaN(f) {
+			return append(bs, 0xf9, 0x7e, 0x00)
+		} else if h, ok := float16Bits(f); ok {
+			return binary.BigEndian.AppendUint16(append(bs, 0xf9), h)
+		} else if float64(float32(f)) == f {
+			bits = 32
+		} else {
+			bits = 64
+		}
+	}
+	if bits == 32 {
+		return binary.BigEndian.AppendUint32(append(bs, 0xfa), math.Float32bits(float32(f)))
+	}
+	return binary.BigEndian.AppendUint64(append(bs, 0xfb), math.Float64bits(f))
+}
+
+func (e cborEncoder) appendBigInt(bs []byte, x *big.Int) []byte {
+	if x.Sign() >= 0 {
+		if x.IsUint64() {
+			return e.appendHead(bs, cborUint, x.Uint64())
+		}
+		return e.appendString(e.appendHead(bs, cborTag, cborTagPosBignum), cborBytes, string(x.Bytes()))
+	}
+
+	n := new(big.Int).Neg(x)
+	n.Sub(n, big.NewInt(1))
+	if n.IsUint64() {
+		return e.appendHead(bs, cborNegInt, n.Uint64())
+	}
+	return e.appendString(e.appendHead(bs, cborTag, cborTagNegBignum), cborBytes, string(n.Bytes()))
+}
+
+func (e cborEncoder) appendJSONNumber(bs []byte, n json.Number) ([]byte, error) {
+	if !strings.ContainsAny(n.String(), ".eE") {
+		if x, ok := new(big.Int).SetString(n.String(), 10); ok {
+			return e.appendBigInt(bs, x), nil
+		}
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, err
+	}
+	return e.appendFloat(bs, f, 64), nil
+}
+
+// float16Bits returns the IEEE 754 half precision encoding of f, when it represents f exactly.
+func float16Bits(f float64) (uint16, bool) {
+	f32 := float32(f)
+	if float64(f32) != f {
+		return 0, false
+	}
+
+	bits := math.Float32bits(f32)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xff) - 127
+	mantissa := bits & 0x7fffff
+	switch {
+	case exp == 128:
+		return sign | 0x7c00, mantissa == 0
+	case exp == -127:
+		return sign, mantissa == 0
+	case exp >= -14 && exp <= 15:
+		return sign | uint16(exp+15)<<10 | uint16(mantissa>>13), mantissa&0x1fff == 0
+	case exp >= -24 && exp < -14:
+		full, shift := mantissa|1<<23, uint(-exp-1)
+		return sign | uint16(full>>shift), full&(1<<shift-1) == 0
+	}
+	return 0, false
+}
+
+func float16ToFloat64(h uint16) float64 {
+	exp, mantissa := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa != 0 {
+			return math.NaN()
+		}
+		f = math.Inf(1)
+	default:
+		f = math.Ldexp(mantissa+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+func decodeCBOR(bs []byte) (any, error) {
+	decoder := &cborDecoder{data: bs}
+	value, err := decoder.decode(0)
+	if err != nil {
+		return nil, err
+	} else if decoder.offset != len(bs) {
+		return nil, fmt.Errorf("error convert from CBOR, %d unexpected bytes after value", len(bs)-decoder.offset)
+	}
+	return value, nil
+}
+
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *cborDecoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("error convert from CBOR, %s at offset %d", fmt.Sprintf(format, args...), d.offset)
+}
+
+func (d *cborDecoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.offset) {
+		return nil, d.errorf("unexpected end of data")
+	}
+	bs := d.data[d.offset : d.offset+int(n)]
+	d.offset += int(n)
+	return bs, nil
+}
+
+// head reads the initial byte of an item and its argument, returning indefinite as true for lengths encoded as 31.
+func (d *cborDecoder) head() (major, info byte, n uint64, indefinite bool, err error) {
+	bs, err := d.read(1)
+	if err != nil {
+		return 0, 0, 0, false, err
+	}
+	major, info = bs[0]>>5, bs[0]&0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), false, nil
+	case info <= 27:
+		arg, err := d.read(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, false, err
+		}
+		for _, b := range arg {
+			n = n<<8 | uint64(b)
+		}
+		return major, info, n, false, nil
+	case info == 31 && major >= cborBytes && major != cborTag:
+		return major, info, 0, true, nil
+	default:
+		d.offset--
+		return 0, 0, 0, false, d.errorf("invalid initial byte 0x%02x", bs[0])
+	}
+}
+
+// atBreak consumes the break that ends an indefinite-length value, reporting whether it was found.
+func (d *cborDecoder) atBreak() bool {
+	if d.offset < len(d.data) && d.data[d.offset] == cborBreak {
+		d.offset++
+		return true
+	}
+	return false
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > cborMaxDepth {
+		return nil, d.errorf("maximum nesting depth exceeded")
+	}
+
+	major, info, n, indefinite, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUint:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			x := new(big.Int).SetUint64(n)
+			return x.Neg(x).Sub(x, big.NewInt(1)), nil
+		}
+		return -1 - int64(n), nil
+	case cborBytes, cborText:
+		bs, err := d.decodeString(major, n, indefinite)
+		if err != nil {
+			return nil, err
+		} else if major == cborBytes {
+			return bs, nil
+		} else if !utf8.Valid(bs) {
+			return nil, d.errorf("invalid UTF-8 text")
+		}
+		return string(bs), nil
+	case cborArray:
+		if !indefinite && n > uint64(len(d.data)-d.offset) {
+			return nil, d.errorf("unexpected end of data")
+		}
+		items := make([]any, 0, min(n, uint64(len(d.data)-d.offset)))
+		for i := uint64(0); indefinite && !d.atBreak() || !indefinite && i < n; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		if !indefinite && n > uint64(len(d.data)-d.offset)/2 {
+			return nil, d.errorf("unexpected end of data")
+		}
+		fields := map[string]any{}
+		for i := uint64(0); indefinite && !d.atBreak() || !indefinite && i < n; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			name, err := ToStringWithErr(key)
+			if err != nil {
+				return nil, d.errorf("unsupported map key %T", key)
+			}
+			if fields[name], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return fields, nil
+	case cborTag:
+		content, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeTag(n, content)
+	default:
+		return d.decodeSimple(info, n)
+	}
+}
+
+func (d *cborDecoder) decodeString(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		bs, err := d.read(n)
+		return append([]byte(nil), bs...), err
+	}
+
+	var buf []byte
+	for !d.atBreak() {
+		chunkMajor, _, chunkLength, chunkIndefinite, err := d.head()
+		if err != nil {
+			return nil, err
+		} else if chunkMajor != major || chunkIndefinite {
+			return nil, d.errorf("invalid chunk of indefinite-length string")
+		}
+		chunk, err := d.read(chunkLength)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, chunk...)
+	}
+	return buf, nil
+}
+
+func (d *cborDecoder) decodeTag(tag uint64, content any) (any, error) {
+	switch tag {
+	case cborTagTimeString:
+		s, ok := content.(string)
+		if !ok {
+			return nil, d.errorf("invalid content %T of time tag", content)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, d.errorf("invalid time %q", s)
+		}
+		return t, nil
+	case cborTagTimeEpoch:
+		switch v := content.(type) {
+		case int64:
+			return time.Unix(v, 0).UTC(), nil
+		case float64:
+			sec, frac := math.Modf(v)
+			return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), nil
+		}
+		return nil, d.errorf("invalid content %T of epoch tag", content)
+	case cborTagPosBignum, cborTagNegBignum:
+		bs, ok := content.([]byte)
+		if !ok {
+			return nil, d.errorf("invalid content %T of bignum tag", content)
+		}
+		x := new(big.Int).SetBytes(bs)
+		if tag == cborTagNegBignum {
+			x.Neg(x).Sub(x, big.NewInt(1))
+		}
+		return x, nil
+	default:
+		return content, nil
+	}
+}
+
+func (d *cborDecoder) decodeSimple(info byte, n uint64) (any, error) {
+	switch info {
+	case 20, 21:
+		return info == 21, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return float16ToFloat64(uint16(n)), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(n))), nil
+	case 27:
+		return math.Float64frombits(n), nil
+	case 31:
+		return nil, d.errorf("unexpected break")
+	default:
+		return nil, d.errorf("unsupported simple value %d", n)
+	}
+}
@@ -0,0 +1,261 @@
+package converter
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type cborTestReading struct {
+	Sensor string    `cbor:"sensor"`
+	Value  float64   `cbor:"value"`
+	At     time.Time `cbor:"at"`
+	Tags   []string  `cbor:"tags,omitempty"`
+	Raw    []byte    `json:"raw"`
+	Serial *big.Int  `cbor:"serial"`
+}
+
+func TestToCBORWithErr(t *testing.T) {
+	bignum, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{"Nil", nil, "f6", false},
+		{"Bool", false, "f4", false},
+		{"Small uint", 23, "17", false},
+		{"Uint8", 24, "1818", false},
+		{"Uint16", 1000, "1903e8", false},
+		{"Uint32", 1000000, "1a000f4240", false},
+		{"Uint64", uint64(math.MaxUint64), "1bffffffffffffffff", false},
+		{"Negative", -1000, "3903e7", false},
+		{"Min int64", int64(math.MinInt64), "3b7fffffffffffffff", false},
+		{"Float32", float32(100000), "fa47c35000", false},
+		{"Float64", 1.1, "fb3ff199999999999a", false},
+		{"Text", "IETF", "6449455446", false},
+		{"Bytes", []byte{1, 2, 3, 4}, "4401020304", false},
+		{"Array", []any{1, []int{2, 3}}, "8201820203", false},
+		{"Map sorted by encoded key", map[any]int{"aa": 1, "b": 2, 10: 3}, "a30a0361620262616101", false},
+		{"Struct in field order", struct {
+			B int `cbor:"b"`
+			A int `cbor:"a"`
+		}{1, 2}, "a2616201616102", false},
+		{"Time", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), "c074323031332d30332d32315432303a30343a30305a", false},
+		{"Bignum", bignum, "c249010000000000000000", false},
+		{"Negative bignum", new(big.Int).Neg(new(big.Int).Add(bignum, big.NewInt(1))),
+			"c349010000000000000000", false},
+		{"Big int value", *big.NewInt(-1), "20", false},
+		{"Unsupported", complex(1, 2), "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToCBORWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToCBORWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if hex.EncodeToString(got) != tc.want {
+				t.Errorf("ToCBORWithErr() = %x, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDeterministicCBORWithErr(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"Half float", 1.5, "f93e00"},
+		{"Half float zero", 0.0, "f90000"},
+		{"Half float subnormal", 5.960464477539063e-8, "f90001"},
+		{"Half float max", 65504.0, "f97bff"},
+		{"Infinity", math.Inf(-1), "f9fc00"},
+		{"NaN", math.NaN(), "f97e00"},
+		{"Single float", 100000.0, "fa47c35000"},
+		{"Double float", 1.1, "fb3ff199999999999a"},
+		{"Struct keys sorted", struct {
+			Name  string  `cbor:"name"`
+			Price float64 `cbor:"a"`
+		}{"x", 1.5}, "a26161f93e00646e616d656178"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToDeterministicCBORWithErr(tc.input)
+			if err != nil {
+				t.Fatalf("ToDeterministicCBORWithErr() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tc.want {
+				t.Errorf("ToDeterministicCBORWithErr() = %x, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromCBORWithErr(t *testing.T) {
+	bignum, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	testCases := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr bool
+	}{
+		{name: "Uint64", input: "1bffffffffffffffff", want: uint64(math.MaxUint64)},
+		{name: "Negative", input: "3903e7", want: int64(-1000)},
+		{name: "Negative beyond int64", input: "3bffffffffffffffff", want: new(big.Int).Neg(bignum)},
+		{name: "Bignum", input: "c249010000000000000000", want: bignum},
+		{name: "Half float", input: "f97bff", want: 65504.0},
+		{name: "Half float subnormal", input: "f90001", want: 5.960464477539063e-8},
+		{name: "Single float", input: "fa47c35000", want: 100000.0},
+		{name: "Undefined", input: "f7", want: nil},
+		{name: "Epoch time", input: "c11a514b67b0", want: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{name: "Epoch float time", input: "c1fb41d452d9ec200000",
+			want: time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC)},
+		{name: "Unknown tag", input: "d82076687474703a2f2f7777772e6578616d706c652e636f6d", want: "http://www.example.com"},
+		{name: "Indefinite bytes", input: "5f42010243030405ff", want: []byte{1, 2, 3, 4, 5}},
+		{name: "Indefinite text", input: "7f657374726561646d696e67ff", want: "streaming"},
+		{name: "Indefinite array", input: "9f018202039f0405ffff", want: []any{int64(1), []any{int64(2), int64(3)},
+			[]any{int64(4), int64(5)}}},
+		{name: "Indefinite map", input: "bf6346756ef563416d7421ff", want: map[string]any{"Fun": true, "Amt": int64(-2)}},
+		{name: "Integer keys", input: "a201020304", want: map[string]any{"1": int64(2), "3": int64(4)}},
+		{name: "Truncated", input: "1a000f42", wantErr: true},
+		{name: "Trailing bytes", input: "0102", wantErr: true},
+		{name: "Reserved info", input: "1c", wantErr: true},
+		{name: "Indefinite integer", input: "1f", wantErr: true},
+		{name: "Unexpected break", input: "ff", wantErr: true},
+		{name: "Unterminated indefinite array", input: "9f01", wantErr: true},
+		{name: "Invalid chunk", input: "5f6161ff", wantErr: true},
+		{name: "Invalid UTF-8", input: "62c328", wantErr: true},
+		{name: "Invalid time", input: "c06161", wantErr: true},
+		{name: "Invalid epoch", input: "c16161", wantErr: true},
+		{name: "Invalid bignum", input: "c201", wantErr: true},
+		{name: "Simple value", input: "f810", wantErr: true},
+		{name: "Huge array", input: "9bffffffffffffffff", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got any
+			err := FromCBORWithErr(mustDecodeHex(t, tc.input), &got)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("FromCBORWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FromCBORWithErr() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromCBORWithErrStruct(t *testing.T) {
+	serial, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	want := cborTestReading{
+		Sensor: "t1",
+		Value:  21.5,
+		At:     time.Date(2024, 5, 1, 10, 0, 0, 123, time.FixedZone("", -3*60*60)),
+		Tags:   []string{"a"},
+		Raw:    []byte{0xca, 0xfe},
+		Serial: serial,
+	}
+
+	for _, encode := range []func(any) ([]byte, error){ToCBORWithErr, ToDeterministicCBORWithErr} {
+		bs, err := encode(want)
+		if err != nil {
+			t.Fatalf("encode error = %v", err)
+		}
+		var got cborTestReading
+		if err = FromCBORWithErr(bs, &got); err != nil {
+			t.Fatalf("FromCBORWithErr() error = %v", err)
+		}
+		if !got.At.Equal(want.At) || got.Serial.Cmp(want.Serial) != 0 {
+			t.Errorf("FromCBORWithErr() = %+v, want %+v", got, want)
+		}
+		got.At, got.Serial = want.At, want.Serial
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FromCBORWithErr() = %+v, want %+v", got, want)
+		}
+	}
+
+	var weak struct {
+		Count int    `cbor:"count"`
+		Code  string `cbor:"code"`
+	}
+	if err := FromCBORWithErr(ToCBOR(map[string]any{"count": "3", "code": 7}), &weak); err != nil {
+		t.Fatalf("FromCBORWithErr() error = %v", err)
+	} else if weak.Count != 3 || weak.Code != "7" {
+		t.Errorf("FromCBORWithErr() = %+v", weak)
+	}
+
+	if err := FromCBORWithErr(ToCBOR(1), weak); err == nil {
+		t.Error("FromCBORWithErr() error = nil, want an error for a non pointer dest")
+	}
+}
+
+func TestToDestWithErrCBOR(t *testing.T) {
+	want := cborTestReading{Sensor: "t1", Value: 21.5, At: time.Unix(0, 0).UTC(), Raw: []byte{1}}
+	data := CBOR(ToCBOR(want))
+
+	var got cborTestReading
+	if err := ToDestWithErr(data, &got); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	} else if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToDestWithErr() = %+v, want %+v", got, want)
+	}
+
+	var at time.Time
+	if err := ToDestWithErr(CBOR(ToCBOR(want.At)), &at); err != nil || !at.Equal(want.At) {
+		t.Errorf("ToDestWithErr() = %v, %v, want %v", at, err, want.At)
+	}
+	var n int
+	if err := ToDestWithErr(&data, &n); err == nil {
+		t.Error("ToDestWithErr() error = nil, want an error for a map into an int")
+	}
+	if bs := ToBytes(data); !reflect.DeepEqual(bs, []byte(data)) {
+		t.Errorf("ToBytes() = %x, want %x", bs, []byte(data))
+	}
+}
+
+func TestCBORStep(t *testing.T) {
+	type event struct {
+		ID   int64   `json:"id"`
+		Name string  `json:"name"`
+		Big  uint64  `json:"big"`
+		Rate float64 `json:"rate"`
+	}
+	want := event{ID: 7, Name: "ok", Big: math.MaxUint64, Rate: 0.25}
+
+	pipeline := NewPipeline(CBORStep(), HexStep())
+	encoded, err := pipeline.EncodeToString(want)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	wantHex := "a462696407636269671bffffffffffffffff646e616d65626f6b6472617465fb3fd0000000000000"
+	if encoded != wantHex {
+		t.Errorf("Encode() = %v, want %v", encoded, wantHex)
+	}
+
+	var got event
+	if err = pipeline.Decode(encoded, &got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	} else if got != want {
+		t.Errorf("Decode() = %+v, want %+v", got, want)
+	}
+
+	step := CBORStep()
+	bs, err := step.Encode([]byte("not json"))
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if decoded, err := step.Decode(bs); err != nil || string(decoded) != "not json" {
+		t.Errorf("Decode() = %q, %v, want %q", decoded, err, "not json")
+	}
+}
@@ -0,0 +1,439 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Charset identifies a character encoding supported by ToStringFromCharsetWithErr and ToBytesInCharsetWithErr.
+type Charset int
+
+const (
+	// CharsetUTF8 is UTF-8, the encoding of Go strings.
+	CharsetUTF8 Charset = iota
+	// CharsetUTF16LE is little endian UTF-16.
+	CharsetUTF16LE
+	// CharsetUTF16BE is big endian UTF-16.
+	CharsetUTF16BE
+	// CharsetISO88591 is ISO-8859-1, also known as Latin-1.
+	CharsetISO88591
+	// CharsetISO885915 is ISO-8859-15, also known as Latin-9, which adds the euro sign to Latin-1.
+	CharsetISO885915
+	// CharsetWindows1252 is Windows-1252, the Western European code page of Windows.
+	CharsetWindows1252
+	// CharsetCP850 is code page 850, the Western European code page of DOS.
+	CharsetCP850
+)
+
+// InvalidBytePolicy defines how bytes that are invalid in the source charset, or characters that cannot be
+// represented in the target charset, are handled.
+type InvalidBytePolicy int
+
+const (
+	// InvalidByteReplace replaces them with U+FFFD when decoding and with '?' when encoding to a single byte charset.
+	InvalidByteReplace InvalidBytePolicy = iota
+	// InvalidByteDrop silently removes them.
+	InvalidByteDrop
+	// InvalidByteError fails the conversion.
+	InvalidByteError
+)
+
+var (
+	charsetNames = map[Charset]string{
+		CharsetUTF8:        "UTF-8",
+		CharsetUTF16LE:     "UTF-16LE",
+		CharsetUTF16BE:     "UTF-16BE",
+		CharsetISO88591:    "ISO-8859-1",
+		CharsetISO885915:   "ISO-8859-15",
+		CharsetWindows1252: "Windows-1252",
+		CharsetCP850:       "CP850",
+	}
+	charsetAliases = map[string]Charset{
+		"utf8":        CharsetUTF8,
+		"utf16le":     CharsetUTF16LE,
+		"utf16be":     CharsetUTF16BE,
+		"iso88591":    CharsetISO88591,
+		"latin1":      CharsetISO88591,
+		"l1":          CharsetISO88591,
+		"iso885915":   CharsetISO885915,
+		"latin9":      CharsetISO885915,
+		"windows1252": CharsetWindows1252,
+		"cp1252":      CharsetWindows1252,
+		"cp850":       CharsetCP850,
+		"ibm850":      CharsetCP850,
+	}
+	charsetBOMs = []struct {
+		charset Charset
+		bom     []byte
+	}{
+		{CharsetUTF8, []byte{0xef, 0xbb, 0xbf}},
+		{CharsetUTF16LE, []byte{0xff, 0xfe}},
+		{CharsetUTF16BE, []byte{0xfe, 0xff}},
+	}
+
+	// singleByteCharsets holds, for each single byte charset, the characters of the bytes 0x80 to 0xFF, where 0
+	// marks a byte that is undefined. Bytes below 0x80 are ASCII in all of them.
+	singleByteCharsets = map[Charset]*singleByteCharset{
+		CharsetISO88591: newSingleByteCharset(latin1High(nil)),
+		CharsetISO885915: newSingleByteCharset(latin1High(map[byte]rune{
+			0xA4: 0x20AC, 0xA6: 0x0160, 0xA8: 0x0161, 0xB4: 0x017D, 0xB8: 0x017E, 0xBC: 0x0152, 0xBD: 0x0153, 0xBE: 0x0178,
+		})),
+		CharsetWindows1252: newSingleByteCharset(windows1252High()),
+		CharsetCP850: newSingleByteCharset([128]rune{
+			0x00C7, 0x00FC, 0x00E9, 0x00E2, 0x00E4, 0x00E0, 0x00E5, 0x00E7,
+			0x00EA, 0x00EB, 0x00E8, 0x00EF, 0x00EE, 0x00EC, 0x00C4, 0x00C5,
+			0x00C9, 0x00E6, 0x00C6, 0x00F4, 0x00F6, 0x00F2, 0x00FB, 0x00F9,
+			0x00FF, 0x00D6, 0x00DC, 0x00F8, 0x00A3, 0x00D8, 0x00D7, 0x0192,
+			0x00E1, 0x00ED, 0x00F3, 0x00FA, 0x00F1, 0x00D1, 0x00AA, 0x00BA,
+			0x00BF, 0x00AE, 0x00AC, 0x00BD, 0x00BC, 0x00A1, 0x00AB, 0x00BB,
+			0x2591, 0x2592, 0x2593, 0x2502, 0x2524, 0x00C1, 0x00C2, 0x00C0,
+			0x00A9, 0x2563, 0x2551, 0x2557, 0x255D, 0x00A2, 0x00A5, 0x2510,
+			0x2514, 0x2534, 0x252C, 0x251C, 0x2500, 0x253C, 0x00E3, 0x00C3,
+			0x255A, 0x2554, 0x2569, 0x2566, 0x2560, 0x2550, 0x256C, 0x00A4,
+			0x00F0, 0x00D0, 0x00CA, 0x00CB, 0x00C8, 0x0131, 0x00CD, 0x00CE,
+			0x00CF, 0x2518, 0x250C, 0x2588, 0x2584, 0x00A6, 0x00CC, 0x2580,
+			0x00D3, 0x00DF, 0x00D4, 0x00D2, 0x00F5, 0x00D5, 0x00B5, 0x00FE,
+			0x00DE, 0x00DA, 0x00DB, 0x00D9, 0x00FD, 0x00DD, 0x00AF, 0x00B4,
+			0x00AD, 0x00B1, 0x2017, 0x00BE, 0x00B6, 0x00A7, 0x00F7, 0x00B8,
+			0x00B0, 0x00A8, 0x00B7, 0x00B9, 0x00B3, 0x00B2, 0x25A0, 0x00A0,
+		}),
+	}
+)
+
+type singleByteCharset struct {
+	high   [128]rune
+	encode map[rune]byte
+}
+
+// String returns the canonical name of the charset, e.g. "ISO-8859-1".
+func (c Charset) String() string {
+	if name, ok := charsetNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Charset(%d)", int(c))
+}
+
+// ParseCharset returns the charset with the given name, such as the charset parameter of a Content-Type header.
+// Names are compared case insensitively, ignoring dashes, underscores and spaces, and common aliases such as
+// "latin1" and "cp1252" are accepted.
+//
+// Example:
+//
+//	charset, _ := ParseCharset("iso-8859-1")
+//	fmt.Println(charset) // ISO-8859-1
+func ParseCharset(name string) (Charset, error) {
+	key := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+	if charset, ok := charsetAliases[key]; ok {
+		return charset, nil
+	}
+	return 0, fmt.Errorf("unknown charset: %s", name)
+}
+
+// DetectBOM reports the charset indicated by the byte order mark at the start of the given value, which is first
+// converted to a byte slice using ToBytesWithErr. UTF-8 and UTF-16 marks are recognized.
+//
+// Example:
+//
+//	charset, ok := DetectBOM([]byte{0xff, 0xfe, 'h', 0x00})
+//	fmt.Println(charset, ok) // UTF-16LE true
+func DetectBOM(a any) (Charset, bool) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return 0, false
+	}
+	charset, bom := detectBOM(bs)
+	return charset, bom != nil
+}
+
+// StripBOM removes the byte order mark from the start of the given value, panicking if the conversion fails.
+// See StripBOMWithErr.
+func StripBOM(a any) []byte {
+	bs, err := StripBOMWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// StripBOMWithErr converts the given value to a byte slice using ToBytesWithErr and removes the UTF-8 or UTF-16
+// byte order mark from its start, if any.
+//
+// Example:
+//
+//	bs, _ := StripBOMWithErr("\ufeffid;name")
+//	fmt.Println(string(bs)) // id;name
+func StripBOMWithErr(a any) ([]byte, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	_, bom := detectBOM(bs)
+	return bs[len(bom):], nil
+}
+
+// ToStringFromCharset decodes the given value from the given charset, panicking if decoding fails.
+// See ToStringFromCharsetWithErr.
+func ToStringFromCharset(a any, charset Charset, policy InvalidBytePolicy) string {
+	s, err := ToStringFromCharsetWithErr(a, charset, policy)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToStringFromCharsetWithErr converts the given value to a byte slice using ToBytesWithErr and decodes it from the
+// given charset into a UTF-8 string.
+//
+// When the bytes start with a UTF-8 or UTF-16 byte order mark, the mark is removed and the charset it indicates
+// takes precedence over the given one. Bytes that are invalid in the charset are handled according to the policy.
+//
+// Parameters:
+//   - a: The value holding the encoded bytes.
+//   - charset: The charset the bytes are encoded in.
+//   - policy: How invalid bytes are handled.
+//
+// Returns:
+//   - string: The decoded string.
+//   - error: An error is returned if the charset is unknown or, with InvalidByteError, if an invalid byte is found.
+//
+// Example:
+//
+//	s, _ := ToStringFromCharsetWithErr([]byte{'S', 0xe3, 'o', ' ', 'P', 'a', 'u', 'l', 'o'}, CharsetISO88591,
+//		InvalidByteError)
+//	fmt.Println(s) // São Paulo
+//
+//	s, _ = ToStringFromCharsetWithErr([]byte{0x80, '1', '0'}, CharsetWindows1252, InvalidByteError)
+//	fmt.Println(s) // €10
+func ToStringFromCharsetWithErr(a any, charset Charset, policy InvalidBytePolicy) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	if detected, bom := detectBOM(bs); bom != nil {
+		charset, bs = detected, bs[len(bom):]
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(bs))
+	invalid := func(offset int) error {
+		switch policy {
+		case InvalidByteDrop:
+			return nil
+		case InvalidByteError:
+			return fmt.Errorf("error convert from %s, invalid byte 0x%02x at offset %d", charset, bs[offset], offset)
+		default:
+			sb.WriteRune(utf8.RuneError)
+			return nil
+		}
+	}
+
+	switch charset {
+	case CharsetUTF8:
+		for i := 0; i < len(bs); {
+			r, size := utf8.DecodeRune(bs[i:])
+			if r == utf8.RuneError && size == 1 {
+				if err = invalid(i); err != nil {
+					return "", err
+				}
+			} else {
+				sb.WriteRune(r)
+			}
+			i += size
+		}
+	case CharsetUTF16LE, CharsetUTF16BE:
+		unit := func(i int) rune {
+			if charset == CharsetUTF16LE {
+				return rune(bs[i]) | rune(bs[i+1])<<8
+			}
+			return rune(bs[i])<<8 | rune(bs[i+1])
+		}
+		for i := 0; i < len(bs); {
+			if i+1 >= len(bs) {
+				if err = invalid(i); err != nil {
+					return "", err
+				}
+				break
+			}
+			r, size := unit(i), 2
+			if utf16.IsSurrogate(r) {
+				if i+3 < len(bs) {
+					r = utf16.DecodeRune(r, unit(i+2))
+					size = 4
+				} else {
+					r = utf8.RuneError
+				}
+				if r == utf8.RuneError {
+					if err = invalid(i); err != nil {
+						return "", err
+					}
+					i += 2
+					continue
+				}
+			}
+			sb.WriteRune(r)
+			i += size
+		}
+	default:
+		table, ok := singleByteCharsets[charset]
+		if !ok {
+			return "", fmt.Errorf("error convert from charset, unknown charset %s", charset)
+		}
+		for i, b := range bs {
+			if b < utf8.RuneSelf {
+				sb.WriteByte(b)
+			} else if r := table.high[b-utf8.RuneSelf]; r != 0 {
+				sb.WriteRune(r)
+			} else if err = invalid(i); err != nil {
+				return "", err
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// ToBytesInCharset encodes the given value in the given charset, panicking if encoding fails.
+// See ToBytesInCharsetWithErr.
+func ToBytesInCharset(a any, charset Charset, policy InvalidBytePolicy) []byte {
+	bs, err := ToBytesInCharsetWithErr(a, charset, policy)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToBytesInCharsetWithErr converts the given value to a string using ToStringWithErr and encodes it in the given
+// charset, without a byte order mark.
+//
+// Characters that cannot be represented in the charset, as well as invalid UTF-8 in the string, are handled
+// according to the policy.
+//
+// Parameters:
+//   - a: The value of any type to be encoded.
+//   - charset: The target charset.
+//   - policy: How characters that cannot be represented are handled.
+//
+// Returns:
+//   - []byte: The encoded bytes.
+//   - error: An error is returned if the charset is unknown or, with InvalidByteError, if a character cannot be
+//     represented.
+//
+// Example:
+//
+//	bs, _ := ToBytesInCharsetWithErr("São Paulo", CharsetISO88591, InvalidByteError)
+//	fmt.Println(bs) // [83 227 111 32 80 97 117 108 111]
+//
+//	bs, _ = ToBytesInCharsetWithErr("10 €", CharsetISO88591, InvalidByteReplace)
+//	fmt.Println(string(bs)) // 10 ?
+func ToBytesInCharsetWithErr(a any, charset Charset, policy InvalidBytePolicy) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+
+	table, singleByte := singleByteCharsets[charset]
+	if !singleByte && charset != CharsetUTF8 && charset != CharsetUTF16LE && charset != CharsetUTF16BE {
+		return nil, fmt.Errorf("error convert to charset, unknown charset %s", charset)
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(s))
+	writeRune := func(r rune) {
+		switch charset {
+		case CharsetUTF16LE, CharsetUTF16BE:
+			units := []uint16{uint16(r)}
+			if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+				units = []uint16{uint16(r1), uint16(r2)}
+			}
+			for _, unit := range units {
+				if charset == CharsetUTF16LE {
+					buf.WriteByte(byte(unit))
+					buf.WriteByte(byte(unit >> 8))
+				} else {
+					buf.WriteByte(byte(unit >> 8))
+					buf.WriteByte(byte(unit))
+				}
+			}
+		case CharsetUTF8:
+			buf.WriteRune(r)
+		default:
+			if r < utf8.RuneSelf {
+				buf.WriteByte(byte(r))
+			} else {
+				buf.WriteByte(table.encode[r])
+			}
+		}
+	}
+
+	for i, r := range s {
+		representable := r != utf8.RuneError || strings.HasPrefix(s[i:], string(utf8.RuneError))
+		if representable && singleByte && r >= utf8.RuneSelf {
+			_, representable = table.encode[r]
+		}
+		if representable {
+			writeRune(r)
+			continue
+		}
+
+		switch policy {
+		case InvalidByteDrop:
+		case InvalidByteError:
+			if r == utf8.RuneError {
+				return nil, fmt.Errorf("error convert to %s, invalid UTF-8 at offset %d", charset, i)
+			}
+			return nil, fmt.Errorf("error convert to %s, character %q at offset %d cannot be represented", charset,
+				r, i)
+		default:
+			if singleByte {
+				buf.WriteByte('?')
+			} else {
+				writeRune(utf8.RuneError)
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func detectBOM(bs []byte) (Charset, []byte) {
+	for _, candidate := range charsetBOMs {
+		if bytes.HasPrefix(bs, candidate.bom) {
+			return candidate.charset, candidate.bom
+		}
+	}
+	return 0, nil
+}
+
+func newSingleByteCharset(high [128]rune) *singleByteCharset {
+	charset := &singleByteCharset{high: high, encode: make(map[rune]byte, len(high))}
+	for i, r := range high {
+		if r != 0 {
+			charset.encode[r] = byte(i + utf8.RuneSelf)
+		}
+	}
+	return charset
+}
+
+func latin1High(overrides map[byte]rune) [128]rune {
+	var high [128]rune
+	for i := range high {
+		b := byte(i + utf8.RuneSelf)
+		high[i] = rune(b)
+		if r, ok := overrides[b]; ok {
+			high[i] = r
+		}
+	}
+	return high
+}
+
+func windows1252High() [128]rune {
+	high := latin1High(nil)
+	copy(high[:32], []rune{
+		0x20AC, 0x0000, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+		0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x0000, 0x017D, 0x0000,
+		0x0000, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+		0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x0000, 0x017E, 0x0178,
+	})
+	return high
+}
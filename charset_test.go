@@ -0,0 +1,139 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToStringFromCharsetWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   []byte
+		charset Charset
+		policy  InvalidBytePolicy
+		want    string
+		wantErr bool
+	}{
+		{"Latin-1", []byte{'S', 0xe3, 'o', ' ', 'P', 'a', 'u', 'l', 'o'}, CharsetISO88591, InvalidByteError, "São Paulo", false},
+		{"Latin-9 euro", []byte{0xa4, '1', '0'}, CharsetISO885915, InvalidByteError, "€10", false},
+		{"Windows-1252", []byte{0x80, ' ', 0x93, 'a', 0x94, ' ', 0xe7}, CharsetWindows1252, InvalidByteError, "€ “a” ç", false},
+		{"CP850", []byte{0x87, 0xc6, 'o'}, CharsetCP850, InvalidByteError, "ção", false},
+		{"Windows-1252 undefined replace", []byte{'a', 0x81, 'b'}, CharsetWindows1252, InvalidByteReplace, "a�b", false},
+		{"Windows-1252 undefined drop", []byte{'a', 0x81, 'b'}, CharsetWindows1252, InvalidByteDrop, "ab", false},
+		{"Windows-1252 undefined error", []byte{'a', 0x81, 'b'}, CharsetWindows1252, InvalidByteError, "", true},
+		{"UTF-8 invalid replace", []byte{'a', 0xff}, CharsetUTF8, InvalidByteReplace, "a�", false},
+		{"UTF-8 BOM", []byte{0xef, 0xbb, 0xbf, 'i', 'd'}, CharsetISO88591, InvalidByteError, "id", false},
+		{"UTF-16LE BOM", []byte{0xff, 0xfe, 'o', 0x00, 0xe9, 0x00}, CharsetISO88591, InvalidByteError, "oé", false},
+		{"UTF-16BE surrogate", []byte{0xd8, 0x3d, 0xde, 0x00}, CharsetUTF16BE, InvalidByteError, "😀", false},
+		{"UTF-16 odd length", []byte{'a', 0x00, 'b'}, CharsetUTF16LE, InvalidByteDrop, "a", false},
+		{"UTF-16 lone surrogate", []byte{0x00, 0xd8}, CharsetUTF16LE, InvalidByteError, "", true},
+		{"Unknown charset", []byte("a"), Charset(99), InvalidByteError, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToStringFromCharsetWithErr(tc.input, tc.charset, tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToStringFromCharsetWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToStringFromCharsetWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToBytesInCharsetWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		charset Charset
+		policy  InvalidBytePolicy
+		want    []byte
+		wantErr bool
+	}{
+		{"Latin-1", "São", CharsetISO88591, InvalidByteError, []byte{'S', 0xe3, 'o'}, false},
+		{"Latin-1 euro replace", "10 €", CharsetISO88591, InvalidByteReplace, []byte("10 ?"), false},
+		{"Latin-1 euro drop", "10 €", CharsetISO88591, InvalidByteDrop, []byte("10 "), false},
+		{"Latin-1 euro error", "10 €", CharsetISO88591, InvalidByteError, nil, true},
+		{"Latin-9 euro", "€", CharsetISO885915, InvalidByteError, []byte{0xa4}, false},
+		{"Windows-1252", "€ “a”", CharsetWindows1252, InvalidByteError, []byte{0x80, ' ', 0x93, 'a', 0x94}, false},
+		{"CP850", "çã", CharsetCP850, InvalidByteError, []byte{0x87, 0xc6}, false},
+		{"UTF-16LE", "é😀", CharsetUTF16LE, InvalidByteError, []byte{0xe9, 0x00, 0x3d, 0xd8, 0x00, 0xde}, false},
+		{"UTF-16BE", "a", CharsetUTF16BE, InvalidByteError, []byte{0x00, 'a'}, false},
+		{"Invalid UTF-8 error", []byte{'a', 0xff}, CharsetUTF8, InvalidByteError, nil, true},
+		{"Invalid UTF-8 replace", []byte{'a', 0xff}, CharsetUTF8, InvalidByteReplace, []byte("a�"), false},
+		{"Unknown charset", "a", Charset(99), InvalidByteError, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToBytesInCharsetWithErr(tc.input, tc.charset, tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToBytesInCharsetWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) && !(len(got) == 0 && len(tc.want) == 0) {
+				t.Errorf("ToBytesInCharsetWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCharsetRoundTrip(t *testing.T) {
+	for _, charset := range []Charset{CharsetISO88591, CharsetISO885915, CharsetWindows1252, CharsetCP850} {
+		for b := 0x80; b <= 0xff; b++ {
+			s, err := ToStringFromCharsetWithErr([]byte{byte(b)}, charset, InvalidByteError)
+			if err != nil {
+				continue
+			}
+			bs := ToBytesInCharset(s, charset, InvalidByteError)
+			if len(bs) != 1 || bs[0] != byte(b) {
+				t.Errorf("%s round trip of 0x%02x = %v", charset, b, bs)
+			}
+		}
+	}
+}
+
+func TestParseCharset(t *testing.T) {
+	testCases := []struct {
+		input   string
+		want    Charset
+		wantErr bool
+	}{
+		{"ISO-8859-1", CharsetISO88591, false},
+		{"latin1", CharsetISO88591, false},
+		{"iso_8859-15", CharsetISO885915, false},
+		{"Windows-1252", CharsetWindows1252, false},
+		{"cp1252", CharsetWindows1252, false},
+		{"IBM850", CharsetCP850, false},
+		{"utf-8", CharsetUTF8, false},
+		{"ebcdic", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseCharset(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseCharset() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseCharset() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBOM(t *testing.T) {
+	if charset, ok := DetectBOM([]byte{0xfe, 0xff, 0x00, 'a'}); !ok || charset != CharsetUTF16BE {
+		t.Errorf("DetectBOM() = %v, %v", charset, ok)
+	}
+	if _, ok := DetectBOM("id"); ok {
+		t.Error("DetectBOM() expected no BOM")
+	}
+	if got := string(StripBOM("\ufeffid;name")); got != "id;name" {
+		t.Errorf("StripBOM() = %q", got)
+	}
+	if got := string(StripBOM("id;name")); got != "id;name" {
+		t.Errorf("StripBOM() = %q", got)
+	}
+}
@@ -0,0 +1,446 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions configures ToCSVWithErr and FromCSVWithErr. The zero value reads and writes comma separated values
+// with a header row, numbers in the Go format and times rendered by ToStringWithErr.
+type CSVOptions struct {
+	// Delimiter separates the cells of a row. When zero, ',' is used.
+	Delimiter rune
+	// Headers maps CSV headers to column names, which are the `csv` tag or the field name, e.g.
+	// {"Valor Total": "total"}.
+	Headers map[string]string
+	// NoHeader indicates that there is no header row, so the columns follow the order of the struct fields.
+	NoHeader bool
+	// DecimalSeparator is the decimal separator of numeric cells, e.g. ',' for "1234,56". When zero, '.' is used.
+	DecimalSeparator rune
+	// ThousandsSeparator groups the digits of numeric cells, e.g. '.' for "1.234,56". When zero, digits are not
+	// grouped. NaN and infinities are written as "NaN", "+Inf" and "-Inf", without separators.
+	ThousandsSeparator rune
+	// TimeLayout is the layout of time.Time and Date cells, e.g. "02/01/2006". When empty, cells are parsed with
+	// ToDestWithErr and rendered with ToStringWithErr.
+	TimeLayout string
+}
+
+// CSVRowError describes a row of a CSV that could not be decoded by FromCSVWithErr.
+type CSVRowError struct {
+	// Line is the line of the row in the input, starting at 1.
+	Line int
+	// Column is the name of the column whose cell could not be converted.
+	Column string
+	// Value is the content of the cell.
+	Value string
+	// Err is the conversion error.
+	Err error
+}
+
+// Error returns the line, column and cause of the error.
+func (e *CSVRowError) Error() string {
+	return fmt.Sprintf("error convert from CSV, line %d, column %q, value %q: %v", e.Line, e.Column, e.Value, e.Err)
+}
+
+// Unwrap returns the conversion error.
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// CSVErrors is returned by FromCSVWithErr when some rows could not be decoded, holding one error per row.
+type CSVErrors []*CSVRowError
+
+// Error returns the errors of all rows, one per line.
+func (e CSVErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors of all rows, so that errors.As can find a *CSVRowError.
+func (e CSVErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+type csvField struct {
+	index []int
+	name  string
+}
+
+// ToCSV converts a slice of structs to CSV, panicking if the conversion fails. See ToCSVWithErr.
+func ToCSV(a any, opts CSVOptions) string {
+	s, err := ToCSVWithErr(a, opts)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToCSVWithErr converts a slice or array of structs, or of pointers to structs, to CSV, one row per element,
+// preceded by a header row unless opts.NoHeader is set.
+//
+// Each exported field is a column named by its `csv` tag, or by the field name when there is no tag. Fields tagged
+// with `csv:"-"` are skipped and the fields of embedded structs are promoted. Cells are rendered with
+// ToStringWithErr, following the number format and time layout of the options, and nil pointers are rendered as
+// empty cells.
+//
+// Parameters:
+//   - a: The slice of structs to be converted.
+//   - opts: The options of the CSV format.
+//
+// Returns:
+//   - string: The CSV text.
+//   - error: An error is returned if the value is not a slice of structs or a cell cannot be converted.
+//
+// Example:
+//
+//	type Payment struct {
+//		ID     int       `csv:"id"`
+//		Amount float64   `csv:"amount"`
+//		PaidAt time.Time `csv:"paid_at"`
+//	}
+//	payments := []Payment{{1, 1234.56, time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)}}
+//	s, _ := ToCSVWithErr(payments, CSVOptions{Delimiter: ';', DecimalSeparator: ',', ThousandsSeparator: '.',
+//		TimeLayout: "02/01/2006"})
+//	fmt.Print(s)
+//	// id;amount;paid_at
+//	// 1;1.234,56;17/10/2026
+func ToCSVWithErr(a any, opts CSVOptions) (string, error) {
+	reflectValue := reflect.ValueOf(indirectValue(a))
+	if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+		return "", fmt.Errorf("error convert to CSV, unsupported type %s", reflectValue.Kind().String())
+	}
+	structType, isPointer, err := csvStructType(reflectValue.Type().Elem())
+	if err != nil {
+		return "", err
+	}
+	fields := csvFields(structType, nil)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = opts.delimiter()
+
+	if !opts.NoHeader {
+		columns := make(map[string]string, len(opts.Headers))
+		for header, column := range opts.Headers {
+			columns[column] = header
+		}
+		header := make([]string, len(fields))
+		for i, field := range fields {
+			header[i] = field.name
+			if mapped, ok := columns[field.name]; ok {
+				header[i] = mapped
+			}
+		}
+		if err = writer.Write(header); err != nil {
+			return "", err
+		}
+	}
+
+	for i := 0; i < reflectValue.Len(); i++ {
+		item := reflectValue.Index(i)
+		record := make([]string, len(fields))
+		if isPointer && item.IsNil() {
+			if err = writer.Write(record); err != nil {
+				return "", err
+			}
+			continue
+		} else if isPointer {
+			item = item.Elem()
+		}
+
+		for j, field := range fields {
+			if record[j], err = opts.formatCell(item.FieldByIndex(field.index)); err != nil {
+				return "", fmt.Errorf("error convert to CSV, row %d, column %q: %w", i, field.name, err)
+			}
+		}
+		if err = writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
+
+// FromCSV decodes CSV into a slice of structs, panicking if decoding fails. See FromCSVWithErr.
+func FromCSV(r io.Reader, dest any, opts CSVOptions) {
+	if err := FromCSVWithErr(r, dest, opts); err != nil {
+		panic(err)
+	}
+}
+
+// FromCSVWithErr decodes the CSV read from r into dest, a pointer to a slice of structs or of pointers to structs,
+// one element per row.
+//
+// Columns are matched to fields by header, case insensitively, using the `csv` tag or the field name, after
+// applying opts.Headers. Unknown columns are ignored. With opts.NoHeader, columns follow the order of the fields.
+// Each cell is converted with ToDestWithErr, after applying the number format and time layout of the options,
+// and empty cells leave the field with its zero value. A UTF-8 byte order mark at the start is ignored.
+//
+// Rows that cannot be decoded are left out of dest, and reported together in a CSVErrors once the whole input has
+// been read, so the valid rows are still available. A malformed CSV stops the decoding with a plain error.
+//
+// Example:
+//
+//	type Payment struct {
+//		ID     int       `csv:"id"`
+//		Amount float64   `csv:"valor"`
+//		PaidAt time.Time `csv:"pago_em"`
+//	}
+//	input := "id;valor;pago_em\n1;1.234,56;17/10/2026\nx;2,00;18/10/2026\n"
+//	var payments []Payment
+//	err := FromCSVWithErr(strings.NewReader(input), &payments, CSVOptions{Delimiter: ';', DecimalSeparator: ',',
+//		ThousandsSeparator: '.', TimeLayout: "02/01/2006"})
+//	fmt.Println(len(payments), err)
+//	// 1 error convert from CSV, line 3, column "id", value "x": strconv.Atoi: parsing "x": invalid syntax
+func FromCSVWithErr(r io.Reader, dest any, opts CSVOptions) error {
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer || reflectDest.IsNil() || reflectDest.Elem().Kind() != reflect.Slice {
+		return errors.New("error convert from CSV, dest must be a non-nil pointer to a slice")
+	}
+	sliceType := reflectDest.Elem().Type()
+	structType, isPointer, err := csvStructType(sliceType.Elem())
+	if err != nil {
+		return err
+	}
+	fields := csvFields(structType, nil)
+
+	reader := csv.NewReader(r)
+	reader.Comma = opts.delimiter()
+	reader.FieldsPerRecord = -1
+
+	var columns []*csvField
+	if opts.NoHeader {
+		for i := range fields {
+			columns = append(columns, &fields[i])
+		}
+	} else {
+		header, err := reader.Read()
+		if err == io.EOF {
+			reflectDest.Elem().Set(reflect.MakeSlice(sliceType, 0, 0))
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error convert from CSV: %w", err)
+		}
+		columns = opts.matchColumns(header, fields)
+	}
+
+	result := reflect.MakeSlice(sliceType, 0, 0)
+	var rowErrs CSVErrors
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("error convert from CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		item := reflect.New(structType)
+		var rowErr *CSVRowError
+		for i, cell := range record {
+			if i >= len(columns) || columns[i] == nil {
+				continue
+			}
+			if err = opts.parseCell(item.Elem().FieldByIndex(columns[i].index), cell); err != nil {
+				rowErr = &CSVRowError{Line: line, Column: columns[i].name, Value: cell, Err: err}
+				break
+			}
+		}
+
+		if rowErr != nil {
+			rowErrs = append(rowErrs, rowErr)
+		} else if isPointer {
+			result = reflect.Append(result, item)
+		} else {
+			result = reflect.Append(result, item.Elem())
+		}
+	}
+
+	reflectDest.Elem().Set(result)
+	if len(rowErrs) > 0 {
+		return rowErrs
+	}
+	return nil
+}
+
+func (o CSVOptions) delimiter() rune {
+	if o.Delimiter == 0 {
+		return ','
+	}
+	return o.Delimiter
+}
+
+func (o CSVOptions) matchColumns(header []string, fields []csvField) []*csvField {
+	columns := make([]*csvField, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if mapped, ok := o.Headers[name]; ok {
+			name = mapped
+		}
+		for j := range fields {
+			if strings.EqualFold(fields[j].name, name) {
+				columns[i] = &fields[j]
+				break
+			}
+		}
+	}
+	return columns
+}
+
+func (o CSVOptions) parseCell(field reflect.Value, cell string) error {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return nil
+	}
+
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := o.parseCell(value.Elem(), cell); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	if o.TimeLayout != "" {
+		switch dest := field.Addr().Interface().(type) {
+		case *time.Time:
+			t, err := time.Parse(o.TimeLayout, cell)
+			*dest = t
+			return err
+		case *Date:
+			t, err := time.Parse(o.TimeLayout, cell)
+			*dest = DateOf(t)
+			return err
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+		reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if o.ThousandsSeparator != 0 {
+			cell = strings.ReplaceAll(cell, string(o.ThousandsSeparator), "")
+		}
+		if o.DecimalSeparator != 0 && o.DecimalSeparator != '.' {
+			cell = strings.ReplaceAll(cell, string(o.DecimalSeparator), ".")
+		}
+	}
+	return ToDestWithErr(cell, field.Addr().Interface())
+}
+
+func (o CSVOptions) formatCell(field reflect.Value) (string, error) {
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+
+	if o.TimeLayout != "" {
+		switch v := field.Interface().(type) {
+		case time.Time:
+			return v.Format(o.TimeLayout), nil
+		case Date:
+			return v.In(time.UTC).Format(o.TimeLayout), nil
+		}
+	}
+
+	if _, ok := lookupFlagSet(field.Type()); !ok {
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return o.formatNumber(strconv.FormatInt(field.Int(), 10)), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return o.formatNumber(strconv.FormatUint(field.Uint(), 10)), nil
+		case reflect.Float32, reflect.Float64:
+			bitSize := field.Type().Bits()
+			if f := field.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+				return strconv.FormatFloat(f, 'f', -1, bitSize), nil
+			}
+			return o.formatNumber(strconv.FormatFloat(field.Float(), 'f', -1, bitSize)), nil
+		}
+	}
+	return ToStringWithErr(field.Interface())
+}
+
+func (o CSVOptions) formatNumber(s string) string {
+	sign, integer, fraction := "", s, ""
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+	if i := strings.IndexByte(integer, '.'); i >= 0 {
+		integer, fraction = integer[:i], integer[i+1:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	for i, digit := range integer {
+		if o.ThousandsSeparator != 0 && i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteRune(o.ThousandsSeparator)
+		}
+		sb.WriteRune(digit)
+	}
+	if fraction != "" {
+		if o.DecimalSeparator != 0 {
+			sb.WriteRune(o.DecimalSeparator)
+		} else {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(fraction)
+	}
+	return sb.String()
+}
+
+func csvStructType(elemType reflect.Type) (reflect.Type, bool, error) {
+	isPointer := elemType.Kind() == reflect.Pointer
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf("error convert CSV, unsupported element type %s", elemType.String())
+	}
+	return elemType, isPointer, nil
+}
+
+func csvFields(structType reflect.Type, index []int) []csvField {
+	var fields []csvField
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		tag := field.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		} else if field.Anonymous && field.Type.Kind() == reflect.Struct && tag == "" {
+			fields = append(fields, csvFields(field.Type, fieldIndex)...)
+			continue
+		} else if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, csvField{index: fieldIndex, name: name})
+	}
+	return fields
+}
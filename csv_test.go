@@ -0,0 +1,164 @@
+package converter
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvTestBase struct {
+	ID int `csv:"id"`
+}
+
+type csvTestPayment struct {
+	csvTestBase
+	Amount   float64   `csv:"amount"`
+	PaidAt   time.Time `csv:"paid_at"`
+	Note     *string   `csv:"note"`
+	Internal string    `csv:"-"`
+	Customer string
+}
+
+func TestToCSVWithErr(t *testing.T) {
+	paidAt := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	payments := []csvTestPayment{
+		{csvTestBase{1}, 1234.56, paidAt, ToPointer("a; b"), "x", "Ana"},
+		{csvTestBase{2}, -1000000, paidAt, nil, "y", "Bruno"},
+	}
+
+	testCases := []struct {
+		name    string
+		input   any
+		opts    CSVOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Default",
+			input: payments,
+			want: "id,amount,paid_at,note,Customer\n" +
+				"1,1234.56,2026-10-17T00:00:00Z,a; b,Ana\n" +
+				"2,-1000000,2026-10-17T00:00:00Z,,Bruno\n",
+		},
+		{
+			name:  "Brazilian format",
+			input: &payments,
+			opts: CSVOptions{Delimiter: ';', DecimalSeparator: ',', ThousandsSeparator: '.',
+				TimeLayout: "02/01/2006", Headers: map[string]string{"Valor": "amount"}},
+			want: "id;Valor;paid_at;note;Customer\n" +
+				"1;1.234,56;17/10/2026;\"a; b\";Ana\n" +
+				"2;-1.000.000;17/10/2026;;Bruno\n",
+		},
+		{
+			name:  "Pointers without header",
+			input: []*csvTestBase{{7}, nil},
+			opts:  CSVOptions{NoHeader: true},
+			want:  "7\n\n",
+		},
+		{name: "Not a slice", input: csvTestBase{}, wantErr: true},
+		{name: "Not structs", input: []int{1}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToCSVWithErr(tc.input, tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToCSVWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToCSVWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromCSVWithErr(t *testing.T) {
+	opts := CSVOptions{Delimiter: ';', DecimalSeparator: ',', ThousandsSeparator: '.', TimeLayout: "02/01/2006",
+		Headers: map[string]string{"Valor Total": "amount"}}
+	input := "\ufeffID;Valor Total;paid_at;note;extra\n" +
+		"1;1.234,56;17/10/2026;ok;ignored\n" +
+		"x;2,00;18/10/2026;;\n" +
+		"3;;;;\n" +
+		"4;1,5;32/10/2026;;\n"
+
+	var payments []csvTestPayment
+	err := FromCSVWithErr(strings.NewReader(input), &payments, opts)
+
+	var rowErrs CSVErrors
+	if !errors.As(err, &rowErrs) || len(rowErrs) != 2 {
+		t.Fatalf("FromCSVWithErr() error = %v, want 2 row errors", err)
+	}
+	if rowErrs[0].Line != 3 || rowErrs[0].Column != "id" || rowErrs[0].Value != "x" {
+		t.Errorf("FromCSVWithErr() first error = %+v", rowErrs[0])
+	}
+	if rowErrs[1].Line != 5 || rowErrs[1].Column != "paid_at" {
+		t.Errorf("FromCSVWithErr() second error = %+v", rowErrs[1])
+	}
+	var rowErr *CSVRowError
+	if !errors.As(err, &rowErr) {
+		t.Error("errors.As() expected a *CSVRowError")
+	}
+
+	want := []csvTestPayment{
+		{csvTestBase: csvTestBase{1}, Amount: 1234.56, PaidAt: time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC),
+			Note: ToPointer("ok")},
+		{csvTestBase: csvTestBase{3}},
+	}
+	if !reflect.DeepEqual(payments, want) {
+		t.Errorf("FromCSVWithErr() = %+v, want %+v", payments, want)
+	}
+}
+
+func TestFromCSVWithErrRoundTrip(t *testing.T) {
+	type row struct {
+		Name   string `csv:"name"`
+		Active bool   `csv:"active"`
+		Day    Date   `csv:"day"`
+	}
+	rows := []*row{{"a", true, Date{2026, time.October, 17}}, {"b, c", false, Date{2026, time.January, 2}}}
+
+	var got []*row
+	if err := FromCSVWithErr(strings.NewReader(ToCSV(rows, CSVOptions{})), &got, CSVOptions{}); err != nil {
+		t.Fatalf("FromCSVWithErr() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("FromCSVWithErr() = %v, want %v", got, rows)
+	}
+
+	var noHeader []row
+	if err := FromCSVWithErr(strings.NewReader("x,true,2026-10-17\n"), &noHeader, CSVOptions{NoHeader: true}); err != nil {
+		t.Fatalf("FromCSVWithErr() error = %v", err)
+	}
+	if len(noHeader) != 1 || noHeader[0].Name != "x" || !noHeader[0].Active {
+		t.Errorf("FromCSVWithErr() = %+v", noHeader)
+	}
+
+	type amount struct {
+		Value float64 `csv:"value"`
+		Small float32 `csv:"small"`
+	}
+	options := CSVOptions{Delimiter: ';', DecimalSeparator: ',', ThousandsSeparator: '.'}
+	amounts := []amount{{math.Inf(1), float32(math.Inf(-1))}, {math.NaN(), 1234.5}}
+	s := ToCSV(amounts, options)
+	if want := "value;small\n+Inf;-Inf\nNaN;1.234,5\n"; s != want {
+		t.Errorf("ToCSV() = %q, want %q", s, want)
+	}
+	var gotAmounts []amount
+	if err := FromCSVWithErr(strings.NewReader(s), &gotAmounts, options); err != nil {
+		t.Fatalf("FromCSVWithErr() error = %v", err)
+	}
+	if len(gotAmounts) != 2 || !math.IsInf(gotAmounts[0].Value, 1) || !math.IsInf(float64(gotAmounts[0].Small), -1) ||
+		!math.IsNaN(gotAmounts[1].Value) || gotAmounts[1].Small != 1234.5 {
+		t.Errorf("FromCSVWithErr() = %+v", gotAmounts)
+	}
+
+	if err := FromCSVWithErr(strings.NewReader("name\n\"a"), &noHeader, CSVOptions{}); err == nil {
+		t.Error("FromCSVWithErr() expected error for malformed CSV")
+	}
+	if err := FromCSVWithErr(strings.NewReader(""), noHeader, CSVOptions{}); err == nil {
+		t.Error("FromCSVWithErr() expected error for non-pointer dest")
+	}
+}
@@ -0,0 +1,178 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DataURI is the content of a "data:" URI, as defined in RFC 2397.
+type DataURI struct {
+	// MediaType is the media type of the data in lower case, e.g. "image/png".
+	MediaType string
+	// Params holds the media type parameters, e.g. "charset", with lower case names.
+	Params map[string]string
+	// Data is the decoded payload.
+	Data []byte
+}
+
+// String returns the data URI with its payload encoded as standard base64, e.g. "data:image/png;base64,iVBO...".
+func (d DataURI) String() string {
+	var sb strings.Builder
+	sb.WriteString("data:")
+	sb.WriteString(formatDataURIMediaType(d.MediaType, d.Params))
+	sb.WriteString(";base64,")
+	sb.WriteString(ToBase64(d.Data))
+	return sb.String()
+}
+
+// CouldBeDataURI checks if the given value is a data URI that can be decoded with FromDataURIWithErr.
+//
+// Example:
+//
+//	fmt.Println(CouldBeDataURI("data:text/plain;base64,SGk=")) // true
+//	fmt.Println(CouldBeDataURI("SGk="))                        // false
+func CouldBeDataURI(a any) bool {
+	_, err := FromDataURIWithErr(a)
+	return err == nil
+}
+
+// ToDataURI converts the given value to a base64 data URI, panicking if the conversion fails.
+// See ToDataURIWithErr.
+func ToDataURI(a any, mediaType string) string {
+	s, err := ToDataURIWithErr(a, mediaType)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToDataURIWithErr converts the given value to a byte slice using ToBytesWithErr and returns it as a data URI with
+// a base64 payload.
+//
+// The media type may carry parameters, e.g. "text/plain;charset=utf-8". When it is empty, it is sniffed from the
+// content with http.DetectContentType.
+//
+// Example:
+//
+//	s, _ := ToDataURIWithErr("Hello", "text/plain")
+//	fmt.Println(s) // data:text/plain;base64,SGVsbG8=
+//
+//	png, _ := os.ReadFile("logo.png")
+//	s, _ = ToDataURIWithErr(png, "")
+//	fmt.Println(s) // data:image/png;base64,iVBORw0KGgo...
+func ToDataURIWithErr(a any, mediaType string) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	if mediaType == "" {
+		mediaType = http.DetectContentType(bs)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return "", fmt.Errorf("error convert to data URI, invalid media type: %w", err)
+	}
+	return DataURI{MediaType: mediaType, Params: params, Data: bs}.String(), nil
+}
+
+// FromDataURI decodes the given data URI, panicking if decoding fails. See FromDataURIWithErr.
+func FromDataURI(a any) DataURI {
+	d, err := FromDataURIWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// FromDataURIWithErr decodes the given data URI, returning its media type, parameters and decoded payload. The
+// value is first converted to a string using ToStringWithErr.
+//
+// Both base64 payloads, in any variant, and percent-encoded payloads are supported. When the URI has no media type,
+// the RFC 2397 default "text/plain;charset=US-ASCII" is used. An empty payload, such as in "data:,", gives empty
+// data.
+//
+// Example:
+//
+//	d, _ := FromDataURIWithErr("data:image/png;base64,iVBORw0KGgo=")
+//	fmt.Println(d.MediaType, len(d.Data)) // image/png 8
+//
+//	d, _ = FromDataURIWithErr("data:,Hello%2C%20World")
+//	fmt.Println(d.MediaType, string(d.Data)) // text/plain Hello, World
+func FromDataURIWithErr(a any) (DataURI, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return DataURI{}, err
+	}
+
+	s = strings.TrimSpace(s)
+	if len(s) < 5 || !strings.EqualFold(s[:5], "data:") {
+		return DataURI{}, errors.New("error convert from data URI, missing \"data:\" scheme")
+	}
+	header, payload, found := strings.Cut(s[5:], ",")
+	if !found {
+		return DataURI{}, errors.New("error convert from data URI, missing \",\" separator")
+	}
+
+	isBase64 := false
+	if i := strings.LastIndex(header, ";"); i >= 0 && strings.EqualFold(strings.TrimSpace(header[i+1:]), "base64") {
+		header, isBase64 = header[:i], true
+	}
+
+	d := DataURI{MediaType: "text/plain", Params: map[string]string{"charset": "US-ASCII"}}
+	if strings.HasPrefix(header, ";") {
+		header = "text/plain" + header
+	}
+	if header != "" {
+		unescaped, err := url.PathUnescape(header)
+		if err != nil {
+			return DataURI{}, fmt.Errorf("error convert from data URI, invalid media type: %w", err)
+		}
+		if d.MediaType, d.Params, err = mime.ParseMediaType(unescaped); err != nil {
+			return DataURI{}, fmt.Errorf("error convert from data URI, invalid media type: %w", err)
+		}
+	}
+
+	if payload == "" {
+		d.Data = []byte{}
+	} else if isBase64 {
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return DataURI{}, fmt.Errorf("error convert from data URI, invalid payload: %w", err)
+		}
+		d.Data, err = FromBase64AutoWithErr(unescaped)
+		if err != nil {
+			return DataURI{}, fmt.Errorf("error convert from data URI, invalid base64 payload: %w", err)
+		}
+	} else {
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return DataURI{}, fmt.Errorf("error convert from data URI, invalid payload: %w", err)
+		}
+		d.Data = []byte(unescaped)
+	}
+	return d, nil
+}
+
+func formatDataURIMediaType(mediaType string, params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(mediaType)
+	for _, name := range names {
+		sb.WriteString(";")
+		sb.WriteString(name)
+		sb.WriteString("=")
+		sb.WriteString(url.PathEscape(params[name]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package converter
+
+import (
+	"bytes"
+	"testing"
+)
+
+var pngHeader = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0, 0, 0, 0}
+
+func TestToDataURIWithErr(t *testing.T) {
+	testCases := []struct {
+		name      string
+		arg       any
+		mediaType string
+		want      string
+		wantErr   bool
+	}{
+		{"Explicit media type", "Hello", "text/plain", "data:text/plain;base64,SGVsbG8=", false},
+		{"Media type with params", "Hello", "text/plain; charset=UTF-8", "data:text/plain;charset=UTF-8;base64,SGVsbG8=", false},
+		{"Sniffed PNG", pngHeader, "", "data:image/png;base64,iVBORw0KGgoAAAAA", false},
+		{"Sniffed text", "Hello", "", "data:text/plain;charset=utf-8;base64,SGVsbG8=", false},
+		{"Invalid media type", "Hello", "text/", "", true},
+		{"Unsupported", func() {}, "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToDataURIWithErr(tc.arg, tc.mediaType)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToDataURIWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToDataURIWithErr() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromDataURIWithErr(t *testing.T) {
+	testCases := []struct {
+		name      string
+		arg       any
+		mediaType string
+		params    map[string]string
+		data      []byte
+		wantErr   bool
+	}{
+		{"Base64", "data:image/png;base64,iVBORw0KGgoAAAAA", "image/png", map[string]string{}, pngHeader, false},
+		{"Base64 URL", "data:application/octet-stream;base64,-_8", "application/octet-stream", map[string]string{}, []byte{0xfb, 0xff}, false},
+		{"Params", "data:text/plain;charset=UTF-8;base64,SGVsbG8=", "text/plain", map[string]string{"charset": "UTF-8"}, []byte("Hello"), false},
+		{"Percent encoded", "data:,Hello%2C%20World", "text/plain", map[string]string{"charset": "US-ASCII"}, []byte("Hello, World"), false},
+		{"Only params", "data:;charset=utf-8,Ol%C3%A1", "text/plain", map[string]string{"charset": "utf-8"}, []byte("Olá"), false},
+		{"Upper case scheme", "DATA:text/html,%3Cb%3E", "text/html", map[string]string{}, []byte("<b>"), false},
+		{"Empty base64", "data:text/plain;base64,", "text/plain", map[string]string{}, []byte{}, false},
+		{"Empty", "data:,", "text/plain", map[string]string{"charset": "US-ASCII"}, []byte{}, false},
+		{"Missing scheme", "iVBORw0KGgo=", "", nil, nil, true},
+		{"Missing separator", "data:text/plain;base64", "", nil, nil, true},
+		{"Invalid base64", "data:image/png;base64,!!!", "", nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromDataURIWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromDataURIWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			} else if tc.wantErr {
+				return
+			}
+			if got.MediaType != tc.mediaType || !bytes.Equal(got.Data, tc.data) || ToString(got.Params) != ToString(tc.params) {
+				t.Errorf("FromDataURIWithErr() = %+v, want %s %v %v", got, tc.mediaType, tc.params, tc.data)
+			}
+		})
+	}
+}
+
+func TestDataURIRoundTrip(t *testing.T) {
+	uri := ToDataURI(pngHeader, "")
+	if !CouldBeDataURI(uri) {
+		t.Fatalf("CouldBeDataURI(%s) = false", uri)
+	}
+	if got := FromDataURI(uri); !bytes.Equal(got.Data, pngHeader) || got.String() != uri {
+		t.Errorf("FromDataURI() = %+v", got)
+	}
+
+	empty, err := FromDataURIWithErr(ToDataURI("", ""))
+	if err != nil || empty.Data == nil || len(empty.Data) != 0 {
+		t.Errorf("FromDataURIWithErr() = %+v, %v, want empty data", empty, err)
+	}
+}
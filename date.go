@@ -0,0 +1,190 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// Date is a civil date, a year, month and day without a time of day or a location. Unlike the time.Time returned
+// by ToDateWithErr, a Date never shifts to another day when serialized or read in a different time zone.
+//
+// The zero value has no year, month or day and is reported by IsZero.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the civil date of the given time, as seen in the time's own location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// DaysBetween returns the number of days from 'from' to 'to', negative when 'to' comes first.
+//
+// Example:
+//
+//	from := Date{2026, time.October, 17}
+//	to := Date{2026, time.December, 25}
+//	fmt.Println(DaysBetween(from, to)) // 69
+func DaysBetween(from, to Date) int {
+	return int((to.In(time.UTC).Unix() - from.In(time.UTC).Unix()) / secondsPerDay)
+}
+
+// String returns the date in the ISO 8601 "2006-01-02" form.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// IsZero reports whether the date is the zero value.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid reports whether the date exists in the calendar, e.g. 2026-02-30 does not.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the first instant of the date in the given location. A nil location means UTC.
+func (d Date) In(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// At returns the instant of the date at the given time of day in the given location. A nil location means UTC.
+func (d Date) At(tod TimeOfDay, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(d.Year, d.Month, d.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc)
+}
+
+// AddDays returns the date n days later, or earlier when n is negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// AddDate returns the date obtained by adding the given number of years, months and days, normalized like
+// time.Time.AddDate.
+func (d Date) AddDate(years, months, days int) Date {
+	return DateOf(d.In(time.UTC).AddDate(years, months, days))
+}
+
+// Weekday returns the day of the week of the date.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// Before reports whether the date comes before 'other'.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether the date comes after 'other'.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Compare returns -1 if the date comes before 'other', +1 if it comes after and 0 if they are the same.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return compareInts(d.Year, other.Year)
+	case d.Month != other.Month:
+		return compareInts(int(d.Month), int(other.Month))
+	default:
+		return compareInts(d.Day, other.Day)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler using the "2006-01-02" form.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler accepting any input supported by ToCivilDateWithErr.
+func (d *Date) UnmarshalText(data []byte) error {
+	date, err := ToCivilDateWithErr(string(data))
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, rendering the date as a JSON string in the "2006-01-02" form.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the date unchanged.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// CouldBeCivilDate checks if the given value can be converted to a Date using ToCivilDateWithErr.
+func CouldBeCivilDate(a any) bool {
+	_, err := ToCivilDateWithErr(a)
+	return err == nil
+}
+
+// ToCivilDate converts the given value to a Date, panicking if the conversion fails.
+// See ToCivilDateWithErr for the accepted inputs.
+func ToCivilDate(a any) Date {
+	d, err := ToCivilDateWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// ToCivilDateWithErr converts the given value to a Date. A Date is returned as is, a time.Time is reduced to its
+// civil date in its own location and any other value is first parsed with ToTimeWithErr, so every layout it
+// accepts can be used.
+//
+// Example:
+//
+//	d, err := ToCivilDateWithErr("2026-10-17")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(d.AddDays(15)) // 2026-11-01
+func ToCivilDateWithErr(a any) (Date, error) {
+	switch v := indirectValue(a).(type) {
+	case Date:
+		return v, nil
+	case time.Time:
+		return DateOf(v), nil
+	}
+	t, err := ToTimeWithErr(a)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
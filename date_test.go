@@ -0,0 +1,107 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToCivilDateWithErr(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+
+	testCases := []struct {
+		name    string
+		input   any
+		want    Date
+		wantErr bool
+	}{
+		{"Date only", "2026-10-17", Date{2026, time.October, 17}, false},
+		{"RFC3339", "2026-10-17T23:30:00-03:00", Date{2026, time.October, 17}, false},
+		{"Time in zone", time.Date(2026, time.October, 17, 23, 0, 0, 0, saoPaulo), Date{2026, time.October, 17}, false},
+		{"Date", Date{2026, time.October, 17}, Date{2026, time.October, 17}, false},
+		{"Pointer", &Date{2026, time.October, 17}, Date{2026, time.October, 17}, false},
+		{"Invalid", "not a date", Date{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToCivilDateWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToCivilDateWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToCivilDateWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	d := Date{2026, time.October, 17}
+
+	if got := d.AddDays(15); got != (Date{2026, time.November, 1}) {
+		t.Errorf("AddDays() = %v, want 2026-11-01", got)
+	}
+	if got := d.AddDays(-17); got != (Date{2026, time.September, 30}) {
+		t.Errorf("AddDays() = %v, want 2026-09-30", got)
+	}
+	if got := DaysBetween(d, Date{2026, time.December, 25}); got != 69 {
+		t.Errorf("DaysBetween() = %d, want 69", got)
+	}
+	if got := DaysBetween(Date{2027, time.March, 1}, Date{2026, time.March, 1}); got != -365 {
+		t.Errorf("DaysBetween() = %d, want -365", got)
+	}
+	if !d.Before(d.AddDays(1)) || !d.After(d.AddDays(-1)) {
+		t.Error("Before()/After() returned unexpected results")
+	}
+	if (Date{2026, time.February, 30}).IsValid() {
+		t.Error("IsValid() = true for 2026-02-30")
+	}
+	if got := d.Weekday(); got != time.Saturday {
+		t.Errorf("Weekday() = %v, want Saturday", got)
+	}
+}
+
+func TestDateIn(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	got := Date{2026, time.October, 17}.In(loc)
+	if want := time.Date(2026, time.October, 17, 0, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("In() = %v, want %v", got, want)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	type payload struct {
+		Birthday Date `json:"birthday"`
+	}
+
+	bs, err := json.Marshal(payload{Birthday: Date{2026, time.October, 17}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"birthday":"2026-10-17"}`; string(bs) != want {
+		t.Errorf("json.Marshal() = %s, want %s", bs, want)
+	}
+
+	var got payload
+	if err = ToDestWithErr(map[string]any{"birthday": "2026-10-17"}, &got); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if got.Birthday != (Date{2026, time.October, 17}) {
+		t.Errorf("ToDestWithErr() = %v, want 2026-10-17", got.Birthday)
+	}
+}
+
+func TestToDestWithErrDate(t *testing.T) {
+	var dest Date
+	if err := ToDestWithErr("2026-10-17", &dest); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if dest != (Date{2026, time.October, 17}) {
+		t.Errorf("ToDestWithErr() = %v, want 2026-10-17", dest)
+	}
+	if got := ToString(dest); got != "2026-10-17" {
+		t.Errorf("ToString() = %q, want %q", got, "2026-10-17")
+	}
+}
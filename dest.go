@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // ToDest converts value 'a' into destination 'dest', returning an error if conversion is not successful.
@@ -37,6 +38,16 @@ func ToDest(a, dest any) {
 // The function handles conversions to various types such as Struct, Map, Array, Slice, Boolean, Integer, Unsigned
 // Integer, Float, Complex, and String by leveraging auxiliary functions, namely ToBytesWithErr, ToStringWithErr,
 // ToBoolWithErr, ToIntWithErr, ToUintWithErr, and ToFloat64WithErr.
+// Destinations of type time.Time are filled using ToTimeLenientWithErr, so besides the layouts accepted by
+// ToTimeWithErr they also accept relative expressions such as "now-2h" or "start of month". Destinations of type
+// Date and TimeOfDay are filled using ToCivilDateWithErr and ToTimeOfDayWithErr, and those of type UUID and ULID
+// using ToUUIDWithErr and ToULIDWithErr. Destinations whose type was registered with RegisterFlags are parsed from
+// names such as "read|write". Struct, map, array and slice destinations are decoded as JSON, or as XML with
+// ToDestFromXMLWithErr when the value is an XML document. They are also filled from url.Values and from query
+// strings with a leading "?", such as "?page=2&tag=a&tag=b&filter[status]=paid", with weak typing, repeated keys
+// collected into slices, bracketed keys nested and fields named as in ToURLValuesWithErr. JSON5 documents are
+// decoded with ToDestFromJSON5WithErr instead. Values of type MsgPack are decoded with FromMsgPackWithErr, and
+// those of type CBOR with FromCBORWithErr, whatever the destination.
 // If the given value cannot be converted to the destination type, the function returns an error.
 //
 // Parameters:
@@ -68,13 +79,29 @@ func ToDestWithErr(a, dest any) error {
 	} else if !reflectValue.IsValid() ||
 		(reflectValue.Kind() == reflect.Ptr || reflectValue.Kind() == reflect.Interface) && reflectValue.IsNil() {
 		return errors.New("A is nil or invalid")
+	} else if data, ok := indirectValue(a).(MsgPack); ok {
+		return FromMsgPackWithErr([]byte(data), dest)
+	} else if data, ok := indirectValue(a).(CBOR); ok {
+		return FromCBORWithErr([]byte(data), dest)
+	} else if ok, err := resolveDestImplementsIfPresent(a, reflectDest); ok {
+		return err
 	}
 
 	switch reflectDest.Elem().Kind() {
 	case reflect.Struct, reflect.Map, reflect.Array, reflect.Slice:
+		if values, ok := urlValuesIfPresent(a); ok {
+			return decodeURLValues(values, reflectDest)
+		}
 		bs, err := ToBytesWithErr(a)
 		if err != nil {
 			return err
+		} else if isXMLDocument(bs) {
+			return ToDestFromXMLWithErr(bs, dest)
+		} else if values, ok, err := parseQueryStringIfPresent(bs); ok {
+			if err != nil {
+				return err
+			}
+			return decodeURLValues(values, reflectDest)
 		}
 		return json.Unmarshal(bs, dest)
 	case reflect.String:
@@ -121,3 +148,44 @@ func ToDestWithErr(a, dest any) error {
 
 	return nil
 }
+
+func resolveDestImplementsIfPresent(a any, reflectDest reflect.Value) (bool, error) {
+	switch dest := reflectDest.Interface().(type) {
+	case *time.Time:
+		t, err := ToTimeLenientWithErr(a)
+		if err != nil {
+			return true, err
+		}
+		*dest = t
+		return true, nil
+	case *Date:
+		d, err := ToCivilDateWithErr(a)
+		if err != nil {
+			return true, err
+		}
+		*dest = d
+		return true, nil
+	case *TimeOfDay:
+		t, err := ToTimeOfDayWithErr(a)
+		if err != nil {
+			return true, err
+		}
+		*dest = t
+		return true, nil
+	case *UUID:
+		u, err := ToUUIDWithErr(a)
+		if err != nil {
+			return true, err
+		}
+		*dest = u
+		return true, nil
+	case *ULID:
+		id, err := ToULIDWithErr(a)
+		if err != nil {
+			return true, err
+		}
+		*dest = id
+		return true, nil
+	}
+	return resolveFlagsDestIfPresent(a, reflectDest)
+}
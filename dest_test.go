@@ -206,3 +206,23 @@ func initDestTestCases() []destCase {
 		},
 	}
 }
+
+func TestToDestWithErrInvalidJSON(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		dest  any
+	}{
+		{"Base64 with padding", "SGVsbG8=", &struct{ A string }{}},
+		{"Pairs without leading question mark", "a=1&b=2", &map[string]any{}},
+		{"Plain text", "value param", &[]string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ToDestWithErr(tc.input, tc.dest); err == nil {
+				t.Error("ToDestWithErr() error = nil, want an error")
+			}
+		})
+	}
+}
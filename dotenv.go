@@ -0,0 +1,343 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// dotenvTags are the struct tags that name fields in dotenv files.
+var dotenvTags = []string{"env"}
+
+// ToDotenvMap parses the given dotenv content, panicking if parsing fails. See ToDotenvMapWithErr.
+func ToDotenvMap(a any) map[string]string {
+	m, err := ToDotenvMapWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToDotenvMapWithErr converts the given value to a string using ToStringWithErr and parses it as a dotenv file,
+// returning its variables.
+//
+// The file follows the usual dotenv conventions:
+//   - Each line holds a KEY=VALUE assignment, optionally preceded by "export".
+//   - Blank lines and lines starting with "#" are ignored, and so is the rest of an unquoted value after " #".
+//   - Unquoted values are trimmed.
+//   - Values in single quotes are taken literally and may span lines.
+//   - Values in double quotes may span lines and accept the escapes \n, \r, \t, \", \\ and \$.
+//   - Unquoted and double-quoted values expand $NAME, ${NAME}, ${NAME:-default}, used when NAME is unset or
+//     empty, and ${NAME-default}, used when NAME is unset. Names are looked up among the variables assigned
+//     before in the file, then in the environment of the process. ToDotenvMapWithLookupWithErr replaces the
+//     environment with another source, or with none.
+//
+// Parameters:
+//   - a: The dotenv content, usually a string or a byte slice.
+//
+// Returns:
+//   - map[string]string: The variables assigned in the file.
+//   - error: An error, with the line number, is returned in case of failure to parse.
+//
+// Example:
+//
+//	m, _ := ToDotenvMapWithErr("HOST=db.local # primary\nURL=\"postgres://${HOST}:${PORT:-5432}\"")
+//	fmt.Println(m["URL"]) // postgres://db.local:5432
+func ToDotenvMapWithErr(a any) (map[string]string, error) {
+	return ToDotenvMapWithLookupWithErr(a, os.LookupEnv)
+}
+
+// ToDotenvMapWithLookup parses the given dotenv content, expanding variables with the given lookup, panicking if
+// parsing fails. See ToDotenvMapWithLookupWithErr.
+func ToDotenvMapWithLookup(a any, lookup func(name string) (string, bool)) map[string]string {
+	m, err := ToDotenvMapWithLookupWithErr(a, lookup)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToDotenvMapWithLookupWithErr is like ToDotenvMapWithErr, but variables not assigned before in the file are looked
+// up with the given function instead of the environment of the process. A nil lookup expands them as unset, so the
+// result depends on the content alone.
+//
+// Parameters:
+//   - a: The dotenv content, usually a string or a byte slice.
+//   - lookup: The function returning the value of a variable and whether it is set, or nil.
+//
+// Returns:
+//   - map[string]string: The variables assigned in the file.
+//   - error: An error, with the line number, is returned in case of failure to parse.
+//
+// Example:
+//
+//	env := map[string]string{"PORT": "6543"}
+//	m, _ := ToDotenvMapWithLookupWithErr("URL=db:${PORT:-5432}", func(name string) (string, bool) {
+//		value, ok := env[name]
+//		return value, ok
+//	})
+//	fmt.Println(m["URL"]) // db:6543
+func ToDotenvMapWithLookupWithErr(a any, lookup func(name string) (string, bool)) (map[string]string, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	lookupVariable := func(name string) (string, bool) {
+		if value, ok := values[name]; ok {
+			return value, true
+		} else if lookup == nil {
+			return "", false
+		}
+		return lookup(name)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(StripBOM([]byte(s))), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+
+		key, rest, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !isDotenvName(key) {
+			return nil, fmt.Errorf("error convert from dotenv, line %d, expected KEY=VALUE", lineNumber)
+		}
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value string
+		if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+			quote := rest[0]
+			text := rest[1:]
+			end := dotenvClosingQuote(text, quote)
+			for end < 0 {
+				if i++; i >= len(lines) {
+					return nil, fmt.Errorf("error convert from dotenv, line %d, unterminated quoted value", lineNumber)
+				}
+				text += "\n" + lines[i]
+				end = dotenvClosingQuote(text, quote)
+			}
+			if trailing := strings.TrimSpace(text[end+1:]); trailing != "" && trailing[0] != '#' {
+				return nil, fmt.Errorf("error convert from dotenv, line %d, unexpected %q after quoted value",
+					lineNumber, trailing)
+			}
+
+			value = text[:end]
+			if quote == '"' {
+				if value, err = expandDotenv(value, true, lookupVariable); err != nil {
+					return nil, fmt.Errorf("error convert from dotenv, line %d, %w", lineNumber, err)
+				}
+			}
+		} else {
+			if index := strings.Index(rest, " #"); index >= 0 {
+				rest = rest[:index]
+			} else if index = strings.Index(rest, "\t#"); index >= 0 {
+				rest = rest[:index]
+			}
+			if value, err = expandDotenv(strings.TrimSpace(rest), false, lookupVariable); err != nil {
+				return nil, fmt.Errorf("error convert from dotenv, line %d, %w", lineNumber, err)
+			}
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// ToDestFromDotenv parses the given dotenv content into dest, panicking if the conversion fails.
+// See ToDestFromDotenvWithErr.
+func ToDestFromDotenv(a, dest any) {
+	if err := ToDestFromDotenvWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// ToDestFromDotenvWithErr parses the given dotenv content with ToDotenvMapWithErr and fills dest with its
+// variables, converting each one with the rules of ToDestWithErr.
+//
+// Struct fields are named by their `env` tag or by their name, and matched case insensitively. Nested structs are
+// filled from the variables prefixed by the name of their field and "_", e.g. DB_HOST for the field Host of the
+// field DB. Slices are filled from comma separated values. Fields without a variable are left unchanged, so
+// defaults may be set before the call. A *map[string]string destination receives the variables as they are.
+//
+// Example:
+//
+//	type Config struct {
+//		Port  int           `env:"PORT"`
+//		Debug bool          `env:"DEBUG"`
+//		Hosts []string      `env:"HOSTS"`
+//		TTL   time.Duration `env:"TTL"`
+//	}
+//	var config Config
+//	err := ToDestFromDotenvWithErr("PORT=8080\nDEBUG=true\nHOSTS=a,b\nTTL=90s", &config)
+//	fmt.Println(config, err) // {8080 true [a b] 1m30s} <nil>
+func ToDestFromDotenvWithErr(a, dest any) error {
+	values, err := ToDotenvMapWithErr(a)
+	if err != nil {
+		return err
+	}
+	return weakDecodeMap(values, dest, "", weakDecoder{tags: dotenvTags, separator: ",", prefixSeparator: "_"})
+}
+
+// ToDotenvString converts the given struct or map to dotenv content, panicking if the conversion fails.
+// See ToDotenvStringWithErr.
+func ToDotenvString(a any) string {
+	s, err := ToDotenvStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToDotenvStringWithErr converts the given struct or map to dotenv content that ToDestFromDotenvWithErr reads back.
+//
+// Struct fields are named by their `env` tag or by their name, zero fields with the omitempty option and nil
+// pointers are skipped, and nested structs and maps have their keys prefixed by the name of their field and "_".
+// Values are rendered with ToStringWithErr, slices are joined by commas and values that need it are written in
+// double quotes. Struct fields keep their order, while map keys are sorted.
+//
+// Example:
+//
+//	s, _ := ToDotenvStringWithErr(map[string]any{"PORT": 8080, "GREETING": "hello world"})
+//	fmt.Print(s)
+//	// GREETING="hello world"
+//	// PORT=8080
+func ToDotenvStringWithErr(a any) (string, error) {
+	entries, err := weakFlattenStructOrMap(a, "dotenv", dotenvTags, ",")
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		key := strings.Join(entry.path, "_")
+		if !isDotenvName(key) {
+			return "", fmt.Errorf("error convert to dotenv, invalid key %q", key)
+		}
+		builder.WriteString(key)
+		builder.WriteByte('=')
+		builder.WriteString(quoteDotenv(entry.value))
+		builder.WriteByte('\n')
+	}
+	return builder.String(), nil
+}
+
+func isDotenvName(s string) bool {
+	for i, r := range s {
+		if r != '_' && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && (i == 0 || r != '.' && (r < '0' || r > '9')) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// dotenvClosingQuote returns the index of the quote that closes a value, skipping escaped double quotes, or -1.
+func dotenvClosingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && quote == '"' {
+			i++
+		} else if s[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+// dotenvClosingBrace returns the index of the brace that closes the "${" starting s, counting the braces of nested
+// expansions such as ${A:-${B}}, or -1.
+func dotenvClosingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '$' && i+1 < len(s) && s[i+1] == '{' {
+			depth++
+			i++
+		} else if s[i] == '}' {
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// expandDotenv expands the variables of a value and, when escapes is true, its backslash escapes.
+func expandDotenv(s string, escapes bool, lookup func(string) (string, bool)) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && escapes && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				builder.WriteByte('\n')
+			case 'r':
+				builder.WriteByte('\r')
+			case 't':
+				builder.WriteByte('\t')
+			case '"', '\\', '$':
+				builder.WriteByte(s[i])
+			default:
+				builder.WriteByte('\\')
+				builder.WriteByte(s[i])
+			}
+		case c == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := dotenvClosingBrace(s[i:])
+			if end < 0 {
+				return "", fmt.Errorf("unterminated %q", s[i:])
+			}
+			expression := s[i+2 : i+end]
+			i += end
+
+			name, fallback, unsetOnly := expression, "", false
+			hasFallback := false
+			if index := strings.Index(expression, ":-"); index >= 0 {
+				name, fallback, hasFallback = expression[:index], expression[index+2:], true
+			} else if index = strings.IndexByte(expression, '-'); index >= 0 {
+				name, fallback, hasFallback, unsetOnly = expression[:index], expression[index+1:], true, true
+			}
+			if !isDotenvName(name) {
+				return "", fmt.Errorf("invalid variable %q", name)
+			}
+
+			value, ok := lookup(name)
+			if hasFallback && (!ok || value == "" && !unsetOnly) {
+				expanded, err := expandDotenv(fallback, false, lookup)
+				if err != nil {
+					return "", err
+				}
+				value = expanded
+			}
+			builder.WriteString(value)
+		case c == '$':
+			end := i + 1
+			for end < len(s) && (s[end] == '_' || s[end] >= 'A' && s[end] <= 'Z' || s[end] >= 'a' && s[end] <= 'z' ||
+				end > i+1 && s[end] >= '0' && s[end] <= '9') {
+				end++
+			}
+			if end == i+1 {
+				builder.WriteByte(c)
+				continue
+			}
+			value, _ := lookup(s[i+1 : end])
+			builder.WriteString(value)
+			i = end - 1
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String(), nil
+}
+
+func quoteDotenv(s string) string {
+	if !strings.ContainsAny(s, " \t\r\n#'\"\\$`") {
+		return s
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(s) + `"`
+}
@@ -0,0 +1,187 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type dotenvTestDB struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+}
+
+type dotenvTestConfig struct {
+	Name    string        `env:"APP_NAME"`
+	Debug   bool          `env:"DEBUG"`
+	Hosts   []string      `env:"HOSTS"`
+	TTL     time.Duration `env:"TTL"`
+	DB      dotenvTestDB  `env:"DB"`
+	Level   string
+	Ignored string `env:"-"`
+}
+
+func TestToDotenvMapWithErr(t *testing.T) {
+	t.Setenv("CONVERTER_DOTENV_TEST", "from-env")
+
+	testCases := []struct {
+		name    string
+		input   any
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "Assignments and comments",
+			input: "\ufeff# comment\n\nexport HOST=db.local # primary\r\nEMPTY=\nSPACED =  a b  \nURL=http://x/#anchor\n",
+			want:  map[string]string{"HOST": "db.local", "EMPTY": "", "SPACED": "a b", "URL": "http://x/#anchor"},
+		},
+		{
+			name:  "Quotes",
+			input: "SINGLE='a $HOME \\n'\nDOUBLE=\"tab\\there \\\"q\\\" \\$HOME\" # c\nMULTI=\"line1\nline2\"",
+			want: map[string]string{
+				"SINGLE": `a $HOME \n`,
+				"DOUBLE": "tab\there \"q\" $HOME",
+				"MULTI":  "line1\nline2",
+			},
+		},
+		{
+			name: "Expansion",
+			input: "HOST=db\nURL=\"pg://${HOST}:${PORT:-5432}/$NAME\"\nENV=$CONVERTER_DOTENV_TEST\n" +
+				"EMPTY=\nA=${EMPTY:-x}\nB=${EMPTY-x}\nC=${UNSET_CONVERTER_VAR-y}\nPRICE=$5",
+			want: map[string]string{
+				"HOST": "db", "URL": "pg://db:5432/", "ENV": "from-env",
+				"EMPTY": "", "A": "x", "B": "", "C": "y", "PRICE": "$5",
+			},
+		},
+		{
+			name:  "Nested expansion",
+			input: "B=b\nA=${UNSET_CONVERTER_VAR:-${B}}\nC=\"${UNSET_CONVERTER_VAR:-${UNSET_CONVERTER_VAR:-c}}!\"",
+			want:  map[string]string{"B": "b", "A": "b", "C": "c!"},
+		},
+		{name: "Unterminated nested expansion", input: "A=${B:-${C}", wantErr: true},
+		{name: "Missing equals", input: "HOST", wantErr: true},
+		{name: "Invalid key", input: "1HOST=x", wantErr: true},
+		{name: "Unterminated quote", input: "A=\"abc\nB=1", wantErr: true},
+		{name: "Text after quote", input: "A='x' y", wantErr: true},
+		{name: "Unterminated expansion", input: "A=${B", wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToDotenvMapWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToDotenvMapWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToDotenvMapWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDotenvMapWithLookupWithErr(t *testing.T) {
+	t.Setenv("CONVERTER_DOTENV_TEST", "from-env")
+	input := "HOST=db\nURL=${HOST}:${PORT:-5432}\nENV=${CONVERTER_DOTENV_TEST-none}"
+
+	got, err := ToDotenvMapWithLookupWithErr(input, nil)
+	want := map[string]string{"HOST": "db", "URL": "db:5432", "ENV": "none"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ToDotenvMapWithLookupWithErr() = %q, %v, want %q", got, err, want)
+	}
+
+	env := map[string]string{"PORT": "6543", "HOST": "ignored"}
+	got = ToDotenvMapWithLookup(input, func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	})
+	want = map[string]string{"HOST": "db", "URL": "db:6543", "ENV": "none"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToDotenvMapWithLookup() = %q, want %q", got, want)
+	}
+
+	if _, err = ToDotenvMapWithLookupWithErr("A=${B", nil); err == nil {
+		t.Error("ToDotenvMapWithLookupWithErr() error = nil, want an error for an unterminated expansion")
+	}
+}
+
+func TestToDestFromDotenvWithErr(t *testing.T) {
+	input := "APP_NAME=api\nDEBUG=true\nHOSTS=a, b\nTTL=90s\nDB_HOST=localhost\ndb_port=5432\nlevel=info\nIgnored=x"
+
+	got := dotenvTestConfig{Name: "default"}
+	if err := ToDestFromDotenvWithErr(input, &got); err != nil {
+		t.Fatalf("ToDestFromDotenvWithErr() error = %v", err)
+	}
+	want := dotenvTestConfig{
+		Name:  "api",
+		Debug: true,
+		Hosts: []string{"a", "b"},
+		TTL:   90 * time.Second,
+		DB:    dotenvTestDB{Host: "localhost", Port: 5432},
+		Level: "info",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToDestFromDotenvWithErr() = %+v, want %+v", got, want)
+	}
+
+	var m map[string]string
+	if err := ToDestFromDotenvWithErr("A=1", &m); err != nil || m["A"] != "1" {
+		t.Errorf("ToDestFromDotenvWithErr() = %v, %v, want map[A:1]", m, err)
+	}
+
+	if err := ToDestFromDotenvWithErr("DEBUG=maybe", &got); err == nil {
+		t.Error("ToDestFromDotenvWithErr() error = nil, want an error for an invalid bool")
+	}
+	if err := ToDestFromDotenvWithErr("A=1", got); err == nil {
+		t.Error("ToDestFromDotenvWithErr() error = nil, want an error for a non pointer dest")
+	}
+}
+
+func TestToDotenvStringWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Struct",
+			input: dotenvTestConfig{
+				Name:  "my api",
+				Hosts: []string{"a", "b"},
+				TTL:   time.Minute,
+				DB:    dotenvTestDB{Host: "localhost", Port: 5432},
+				Level: `$x "y"`,
+			},
+			want: "APP_NAME=\"my api\"\nDEBUG=false\nHOSTS=a,b\nTTL=1m0s\nDB_HOST=localhost\nDB_PORT=5432\n" +
+				"Level=\"\\$x \\\"y\\\"\"\n",
+		},
+		{name: "Map", input: map[string]any{"B": "line\nbreak", "A": 1}, want: "A=1\nB=\"line\\nbreak\"\n"},
+		{name: "Invalid key", input: map[string]any{"A B": 1}, wantErr: true},
+		{name: "Unsupported", input: "A=1", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToDotenvStringWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToDotenvStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToDotenvStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDotenvRoundTrip(t *testing.T) {
+	want := dotenvTestConfig{Name: "a 'b' #c", Debug: true, Hosts: []string{"x"}, DB: dotenvTestDB{Port: 1}}
+
+	var got dotenvTestConfig
+	if err := ToDestFromDotenvWithErr(ToDotenvString(want), &got); err != nil {
+		t.Fatalf("ToDestFromDotenvWithErr() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
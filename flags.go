@@ -0,0 +1,179 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FlagInteger is the constraint of the types that can be registered with RegisterFlags.
+type FlagInteger interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// flagSet is the name table of a type registered with RegisterFlags, ordered by value.
+type flagSet struct {
+	names  []string
+	values []uint64
+}
+
+var (
+	flagSetsMutex sync.RWMutex
+	flagSets      = map[reflect.Type]*flagSet{}
+)
+
+// RegisterFlags associates a table of named bit flags with the integer type T, replacing any table registered
+// before. From then on, ToStringWithErr renders values of T as the names of their bits joined by "|", and
+// ToDestWithErr parses such names into destinations of type T.
+//
+// Names are matched case insensitively when parsing, and numbers, such as "4" or "0x4", are accepted as well. When
+// rendering, flags are considered in ascending order of value, and bits without a name are rendered in hexadecimal.
+// A flag with value 0 names the empty set.
+//
+// Only named types can be registered, since a table for a predeclared type such as int would apply to every plain
+// int of the process. RegisterFlags panics for unnamed types.
+//
+// Struct fields of type T are rendered and parsed the same way by the formats decoded field by field, such as
+// ToURLValuesWithErr, ToDotenvStringWithErr and FromLogfmtWithErr. JSON documents are decoded by encoding/json,
+// which does not know the table, so for fields in JSON the type must implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler on top of ToString and ToDestWithErr. Since ToStringWithErr consults the table before the
+// String method, the type may also implement fmt.Stringer that way.
+//
+// Example:
+//
+//	type Permission int
+//
+//	const (
+//		Read Permission = 1 << iota
+//		Write
+//		Exec
+//	)
+//
+//	RegisterFlags(map[string]Permission{"none": 0, "read": Read, "write": Write, "exec": Exec})
+//
+//	fmt.Println(ToString(Read | Write)) // read|write
+//
+//	var p Permission
+//	_ = ToDestWithErr("read|exec", &p)
+//	fmt.Println(int(p)) // 5
+func RegisterFlags[T FlagInteger](names map[string]T) {
+	t := reflect.TypeOf(*new(T))
+	if t.PkgPath() == "" {
+		panic(fmt.Sprintf("error register flags, %s is not a named type", t.String()))
+	}
+
+	set := &flagSet{}
+	for name := range names {
+		set.names = append(set.names, name)
+	}
+	sort.Slice(set.names, func(i, j int) bool {
+		vi, vj := uint64(names[set.names[i]]), uint64(names[set.names[j]])
+		return vi < vj || vi == vj && set.names[i] < set.names[j]
+	})
+	for _, name := range set.names {
+		set.values = append(set.values, uint64(names[name]))
+	}
+
+	flagSetsMutex.Lock()
+	defer flagSetsMutex.Unlock()
+	flagSets[t] = set
+}
+
+func lookupFlagSet(t reflect.Type) (*flagSet, bool) {
+	flagSetsMutex.RLock()
+	defer flagSetsMutex.RUnlock()
+
+	set, ok := flagSets[t]
+	return set, ok
+}
+
+func (f *flagSet) format(reflectValue reflect.Value) string {
+	var u uint64
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		u = uint64(reflectValue.Int())
+		if size := reflectValue.Type().Bits(); size < 64 {
+			u &= 1<<size - 1
+		}
+	default:
+		u = reflectValue.Uint()
+	}
+
+	var parts []string
+	remaining := u
+	for i, value := range f.values {
+		if value == 0 {
+			if u == 0 {
+				return f.names[i]
+			}
+		} else if remaining&value == value {
+			parts = append(parts, f.names[i])
+			remaining &^= value
+		}
+	}
+	if remaining != 0 || len(parts) == 0 {
+		parts = append(parts, "0x"+strconv.FormatUint(remaining, 16))
+	}
+	return strings.Join(parts, "|")
+}
+
+func (f *flagSet) parse(s string, t reflect.Type) (uint64, error) {
+	var u uint64
+tokens:
+	for _, token := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' }) {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		for i, name := range f.names {
+			if strings.EqualFold(name, token) {
+				u |= f.values[i]
+				continue tokens
+			}
+		}
+		value, err := strconv.ParseUint(token, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("error convert to %s, unknown flag %q", t.String(), token)
+		}
+		u |= value
+	}
+	return u, nil
+}
+
+func resolveFlagsDestIfPresent(a any, reflectDest reflect.Value) (bool, error) {
+	elem := reflectDest.Elem()
+	set, ok := lookupFlagSet(elem.Type())
+	if !ok {
+		return false, nil
+	}
+
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return true, err
+	}
+	u, err := set.parse(s, elem.Type())
+	if err != nil {
+		return true, err
+	}
+
+	switch elem.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := int64(u)
+		if size := elem.Type().Bits(); size < 64 && u < 1<<size {
+			i = int64(u<<(64-size)) >> (64 - size)
+		}
+		if elem.OverflowInt(i) {
+			return true, fmt.Errorf("error convert to %s, value %d overflows", elem.Type().String(), u)
+		}
+		elem.SetInt(i)
+	default:
+		if elem.OverflowUint(u) {
+			return true, fmt.Errorf("error convert to %s, value %d overflows", elem.Type().String(), u)
+		}
+		elem.SetUint(u)
+	}
+	return true, nil
+}
@@ -0,0 +1,155 @@
+package converter
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+type testPermission int
+
+const (
+	testRead testPermission = 1 << iota
+	testWrite
+	testExec
+)
+
+type testMode uint8
+
+// testLevel is decoded in JSON documents through the methods below, which delegate to the registered table.
+type testLevel uint16
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	return []byte(ToString(l)), nil
+}
+
+func (l *testLevel) UnmarshalText(text []byte) error {
+	return ToDestWithErr(string(text), l)
+}
+
+func init() {
+	RegisterFlags(map[string]testPermission{"none": 0, "read": testRead, "write": testWrite, "exec": testExec})
+	RegisterFlags(map[string]testMode{"a": 1, "b": 2, "ab": 3})
+	RegisterFlags(map[string]testLevel{"debug": 1, "trace": 2})
+}
+
+func TestFlagsToString(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"Single", testRead, "read"},
+		{"Combined", testRead | testExec, "read|exec"},
+		{"Zero", testPermission(0), "none"},
+		{"Unknown bits", testWrite | 16, "write|0x10"},
+		{"Pointer", ToPointer(testWrite), "write"},
+		{"Lowest values first", testMode(3), "a|b"},
+		{"Zero without name", testMode(0), "0x0"},
+		{"Unregistered", 3, "3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ToString(tc.input); got != tc.want {
+				t.Errorf("ToString() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFlagsToDest(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    testPermission
+		wantErr bool
+	}{
+		{"Names", "read|write", testRead | testWrite, false},
+		{"Case and spaces", " READ | Exec ", testRead | testExec, false},
+		{"Comma", "read,exec", testRead | testExec, false},
+		{"Zero name", "none", 0, false},
+		{"Empty", "", 0, false},
+		{"Number", "0x4|read", testExec | testRead, false},
+		{"Flag value", testWrite, testWrite, false},
+		{"Unknown", "read|delete", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got testPermission
+			err := ToDestWithErr(tc.input, &got)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToDestWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToDestWithErr() = %v, want %v", int(got), int(tc.want))
+			}
+		})
+	}
+
+	var mode testMode
+	if err := ToDestWithErr("0x100", &mode); err == nil {
+		t.Error("ToDestWithErr() expected overflow error")
+	}
+}
+
+func TestRegisterFlagsUnnamed(t *testing.T) {
+	for name, register := range map[string]func(){
+		"int":    func() { RegisterFlags(map[string]int{"a": 1}) },
+		"uint64": func() { RegisterFlags(map[string]uint64{"a": 1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterFlags() expected panic for %s", name)
+				}
+			}()
+			register()
+		}()
+	}
+
+	if got := ToString(5); got != "5" {
+		t.Errorf("ToString() = %v, want 5", got)
+	}
+}
+
+func TestFlagsStructField(t *testing.T) {
+	type config struct {
+		Perm  testPermission `json:"perm" url:"perm" logfmt:"perm"`
+		Level testLevel      `json:"level" url:"level" logfmt:"level"`
+	}
+	want := config{Perm: testRead | testExec, Level: 3}
+
+	var fromQuery config
+	values := url.Values{"perm": {"read|exec"}, "level": {"debug|trace"}}
+	if err := ToDestWithErr(values, &fromQuery); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	} else if fromQuery != want {
+		t.Errorf("ToDestWithErr() = %+v, want %+v", fromQuery, want)
+	}
+
+	line := ToLogfmt(want)
+	if line != "perm=read|exec level=debug|trace" {
+		t.Errorf("ToLogfmt() = %v", line)
+	}
+	var fromLogfmt config
+	if err := FromLogfmtWithErr(line, &fromLogfmt); err != nil {
+		t.Fatalf("FromLogfmtWithErr() error = %v", err)
+	} else if fromLogfmt != want {
+		t.Errorf("FromLogfmtWithErr() = %+v, want %+v", fromLogfmt, want)
+	}
+
+	bs, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	} else if string(bs) != `{"perm":5,"level":"debug|trace"}` {
+		t.Errorf("json.Marshal() = %s", bs)
+	}
+	var fromJSON config
+	if err = ToDestWithErr(`{"perm":5,"level":"trace|debug"}`, &fromJSON); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	} else if fromJSON != want {
+		t.Errorf("ToDestWithErr() = %+v, want %+v", fromJSON, want)
+	}
+}
@@ -130,6 +130,9 @@ func ToFloat64(a any) float64 {
 // This function can convert a varied list of types, including String, Integers, Unsigned Integers, Floats,
 // Complex Numbers, and Booleans.
 //
+// A time.Time is converted to its epoch in DefaultEpochUnit, milliseconds,
+// keeping the fractional part.
+//
 // Note that if the input value can be a pointer or an interface that points to nil, or if the type of data
 // isn't supported for conversion, an error will be returned.
 //
@@ -181,6 +184,11 @@ func ToFloat64WithErr(a any) (float64, error) {
 			return strconv.ParseFloat(string(reflectValue.Bytes()), 64)
 		}
 		return 0, fmt.Errorf("error convert to float, unsupported type %s", reflectValue.Kind().String())
+	case reflect.Struct:
+		if t, ok := timeIfPresent(reflectValue); ok {
+			return epochFloatFromTime(t, DefaultEpochUnit), nil
+		}
+		return 0, fmt.Errorf("error convert to float, unsupported type %s", reflectValue.Kind().String())
 	case reflect.Interface, reflect.Pointer:
 		if reflectValue.IsNil() {
 			return 0, errors.New("error convert to float, it is null")
@@ -0,0 +1,142 @@
+package converter
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// CouldBeHex checks if a given value is a hexadecimal string that can be decoded without causing an error.
+// It utilizes the FromHexWithErr function to attempt the decoding. Empty strings are not considered hexadecimal.
+//
+// Parameters:
+//   - a: The value of any type that is assumed to be a hexadecimal string and attempted to decode.
+//
+// Returns:
+//   - bool: A boolean value indicating whether the provided value could be decoded from a hexadecimal string.
+//
+// Example:
+//
+//	fmt.Println(CouldBeHex("48656c6c6f")) // true
+//	fmt.Println(CouldBeHex("zz"))         // false
+func CouldBeHex(a any) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil || strings.TrimSpace(s) == "" {
+		return false
+	}
+	_, err = FromHexWithErr(s)
+	return err == nil
+}
+
+// ToHex converts a given value to a hexadecimal string representation, panicking if the conversion fails.
+// See ToHexWithErr.
+//
+// Example:
+//
+//	fmt.Println(ToHex("Hello")) // 48656c6c6f
+func ToHex(a any) string {
+	s, err := ToHexWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToHexWithErr converts a given value to a hexadecimal string representation. The value is first converted to a byte
+// slice using ToBytesWithErr, which is then encoded.
+// The output uses lower case digits, two per byte.
+//
+// Parameters:
+//   - a: The value of any type to be converted to a hexadecimal string.
+//
+// Returns:
+//   - string: The hexadecimal encoded string representation of the provided value.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	s, err := ToHexWithErr("Hello")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(s) // 48656c6c6f
+func ToHexWithErr(a any) (string, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return encodeHex(bs), nil
+}
+
+// FromHex decodes a hexadecimal string, panicking if decoding fails. See FromHexWithErr.
+//
+// Example:
+//
+//	fmt.Println(string(FromHex("48656c6c6f"))) // Hello
+func FromHex(a any) []byte {
+	bs, err := FromHexWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// FromHexWithErr decodes a hexadecimal string. The value is first converted to a string using ToStringWithErr.
+// Upper and lower case digits are accepted, as well as an optional "0x" prefix.
+//
+// Parameters:
+//   - a: Any value to be decoded from hexadecimal. This value is internally converted to a string before decoding.
+//
+// Returns:
+//   - []byte: The byte slice resulting from decoding the hexadecimal string.
+//   - error: An error is returned if the value cannot be converted to a string or is not valid hexadecimal.
+//
+// Example:
+//
+//	bs, err := FromHexWithErr("48656c6c6f")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(string(bs)) // Hello
+func FromHexWithErr(a any) ([]byte, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return decodeHex(s)
+}
+
+// FromHexToString decodes a hexadecimal string into a string, panicking if decoding fails.
+// See FromHexToStringWithErr.
+func FromHexToString(a any) string {
+	s, err := FromHexToStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// FromHexToStringWithErr decodes a hexadecimal string into a string using FromHexWithErr.
+//
+// Example:
+//
+//	s, _ := FromHexToStringWithErr("48656c6c6f")
+//	fmt.Println(s) // Hello
+func FromHexToStringWithErr(a any) (string, error) {
+	bs, err := FromHexWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func encodeHex(bs []byte) string {
+	return hex.EncodeToString(bs)
+}
+
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	return hex.DecodeString(s)
+}
@@ -0,0 +1,78 @@
+package converter
+
+import (
+	"testing"
+)
+
+type hexCase struct {
+	name    string
+	arg     any
+	want    string
+	wantErr bool
+}
+
+func TestCouldBeHex(t *testing.T) {
+	testCases := []struct {
+		name string
+		arg  any
+		want bool
+	}{
+		{"Valid Hex String", "48656c6c6f", true},
+		{"Invalid Hex String", "abc", false},
+		{"Empty String", "", false},
+		{"Unsupported Type", func() {}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CouldBeHex(tc.arg); got != tc.want {
+				t.Errorf("CouldBeHex() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToHexWithErr(t *testing.T) {
+	testCases := []hexCase{
+		{"Encode 1", "Hello", "48656c6c6f", false},
+		{"Encode 2", []byte{0, 255}, "00ff", false},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToHexWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToHexWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToHexWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromHexToStringWithErr(t *testing.T) {
+	testCases := []hexCase{
+		{"Round trip 1", "48656c6c6f", string(ToBytes("Hello")), false},
+		{"Round trip 2", "00ff", string(ToBytes([]byte{0, 255})), false},
+		{"Decode 1", "0x48656C6C6F", "Hello", false},
+		{"Decode 2", "zz", "", true},
+		{"Decode 3", "abc", "", true},
+		{"Error", func() {}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromHexToStringWithErr(tc.arg)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("FromHexToStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("FromHexToStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// iniTags are the struct tags that name fields in INI files.
+var iniTags = []string{"ini"}
+
+// ToINIMap parses the given INI content, panicking if parsing fails. See ToINIMapWithErr.
+func ToINIMap(a any) map[string]string {
+	m, err := ToINIMapWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToINIMapWithErr converts the given value to a string using ToStringWithErr and parses it as an INI file,
+// returning its keys prefixed by their section and ".", e.g. "database.host" for the key host of the section
+// [database]. Keys before the first section have no prefix.
+//
+// The file follows the common INI conventions:
+//   - Lines starting with ";" or "#" are comments, and so is the rest of an unquoted value after " ;" or " #".
+//   - Sections are declared as [name], and keys as key = value or key: value.
+//   - Unquoted values are trimmed.
+//   - Values in single quotes are taken literally, and values in double quotes accept the escapes \n, \r, \t, \"
+//     and \\.
+//   - A key repeated in a section keeps its last value.
+//
+// Parameters:
+//   - a: The INI content, usually a string or a byte slice.
+//
+// Returns:
+//   - map[string]string: The keys of the file, prefixed by their section.
+//   - error: An error, with the line number, is returned in case of failure to parse.
+//
+// Example:
+//
+//	m, _ := ToINIMapWithErr("name = api\n\n[database]\nhost = localhost ; primary\nport = 5432")
+//	fmt.Println(m) // map[database.host:localhost database.port:5432 name:api]
+func ToINIMapWithErr(a any) (map[string]string, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	section := ""
+	s = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(string(StripBOM([]byte(s))))
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		} else if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("error convert from INI, line %d, unterminated section", i+1)
+			} else if trailing := strings.TrimSpace(line[end+1:]); trailing != "" && !isINIComment(trailing) {
+				return nil, fmt.Errorf("error convert from INI, line %d, unexpected %q after section", i+1, trailing)
+			}
+			if section = strings.TrimSpace(line[1:end]); section == "" {
+				return nil, fmt.Errorf("error convert from INI, line %d, empty section name", i+1)
+			}
+			continue
+		}
+
+		index := strings.IndexAny(line, "=:")
+		if index <= 0 {
+			return nil, fmt.Errorf("error convert from INI, line %d, expected key = value", i+1)
+		}
+		key := strings.TrimSpace(line[:index])
+		value, err := parseINIValue(strings.TrimSpace(line[index+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("error convert from INI, line %d, %w", i+1, err)
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// ToDestFromINI parses the given INI content into dest, panicking if the conversion fails.
+// See ToDestFromINIWithErr.
+func ToDestFromINI(a, dest any) {
+	if err := ToDestFromINIWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// ToDestFromINIWithErr parses the given INI content with ToINIMapWithErr and fills dest with its keys, converting
+// each one with the rules of ToDestWithErr.
+//
+// Sections fill nested structs and maps, and so do dots in section and key names, e.g. the key port of the section
+// [server.http] fills the field Port of the field HTTP of the field Server. Struct fields are named by their `ini`
+// tag or by their name, and matched case insensitively. Slices are filled from comma separated values. Fields
+// without a key are left unchanged, so defaults may be set before the call. A *map[string]string destination
+// receives the keys as returned by ToINIMapWithErr.
+//
+// A key that also has nested keys, such as "level" next to "level.root", fills a string field with its value, is
+// skipped for a struct field, and is kept under the key "" of a map field.
+//
+// Example:
+//
+//	type Config struct {
+//		Name     string `ini:"name"`
+//		Database struct {
+//			Host string `ini:"host"`
+//			Port int    `ini:"port"`
+//		} `ini:"database"`
+//	}
+//	var config Config
+//	err := ToDestFromINIWithErr("name = api\n[database]\nhost = localhost\nport = 5432", &config)
+//	fmt.Println(config, err) // {api {localhost 5432}} <nil>
+func ToDestFromINIWithErr(a, dest any) error {
+	values, err := ToINIMapWithErr(a)
+	if err != nil {
+		return err
+	}
+	return weakDecodeMap(values, dest, ".", weakDecoder{tags: iniTags, separator: ","})
+}
+
+// ToINIString converts the given struct or map to INI content, panicking if the conversion fails.
+// See ToINIStringWithErr.
+func ToINIString(a any) string {
+	s, err := ToINIStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToINIStringWithErr converts the given struct or map to INI content that ToDestFromINIWithErr reads back.
+//
+// Scalars at the top level are written before any section, and nested structs and maps become sections, named by
+// the path to them joined by ".", e.g. [server.http]. Struct fields are named by their `ini` tag or by their name,
+// zero fields with the omitempty option and nil pointers are skipped. Values are rendered with ToStringWithErr,
+// slices are joined by commas and values that need it are written in double quotes. Struct fields keep their order,
+// while map keys are sorted.
+//
+// Example:
+//
+//	s, _ := ToINIStringWithErr(map[string]any{"name": "api", "database": map[string]any{"host": "localhost"}})
+//	fmt.Print(s)
+//	// name = api
+//	//
+//	// [database]
+//	// host = localhost
+func ToINIStringWithErr(a any) (string, error) {
+	entries, err := weakFlattenStructOrMap(a, "INI", iniTags, ",")
+	if err != nil {
+		return "", err
+	}
+
+	var sections []string
+	bySection := map[string][]weakEntry{}
+	for _, entry := range entries {
+		section := strings.Join(entry.path[:len(entry.path)-1], ".")
+		if _, ok := bySection[section]; !ok && section != "" {
+			sections = append(sections, section)
+		}
+		bySection[section] = append(bySection[section], entry)
+	}
+
+	var builder strings.Builder
+	for i, section := range append([]string{""}, sections...) {
+		if section != "" {
+			if strings.ContainsAny(section, "[]\n") {
+				return "", fmt.Errorf("error convert to INI, invalid section %q", section)
+			} else if i > 1 || len(bySection[""]) > 0 {
+				builder.WriteByte('\n')
+			}
+			builder.WriteString("[" + section + "]\n")
+		}
+		for _, entry := range bySection[section] {
+			key := entry.path[len(entry.path)-1]
+			if key == "" || strings.ContainsAny(key, "=:;#[\n") || strings.TrimSpace(key) != key {
+				return "", fmt.Errorf("error convert to INI, invalid key %q", key)
+			}
+			builder.WriteString(key + " = " + quoteINI(entry.value) + "\n")
+		}
+	}
+	return builder.String(), nil
+}
+
+func parseINIValue(s string) (string, error) {
+	if s == "" || s[0] != '"' && s[0] != '\'' {
+		for _, comment := range []string{" ;", " #", "\t;", "\t#"} {
+			if index := strings.Index(s, comment); index >= 0 {
+				s = s[:index]
+			}
+		}
+		return strings.TrimSpace(s), nil
+	}
+
+	quote := s[0]
+	var builder strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == quote:
+			if trailing := strings.TrimSpace(s[i+1:]); trailing != "" && !isINIComment(trailing) {
+				return "", fmt.Errorf("unexpected %q after quoted value", trailing)
+			}
+			return builder.String(), nil
+		case c == '\\' && quote == '"' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				builder.WriteByte('\n')
+			case 'r':
+				builder.WriteByte('\r')
+			case 't':
+				builder.WriteByte('\t')
+			case '"', '\\':
+				builder.WriteByte(s[i])
+			default:
+				builder.WriteByte('\\')
+				builder.WriteByte(s[i])
+			}
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted value")
+}
+
+func isINIComment(s string) bool {
+	return s[0] == ';' || s[0] == '#'
+}
+
+func quoteINI(s string) string {
+	if s == "" || !strings.ContainsAny(s, ";#\"'\\\r\n\t") && strings.TrimSpace(s) == s {
+		return s
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(s) + `"`
+}
@@ -0,0 +1,126 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+type iniTestConfig struct {
+	Name     string `ini:"name"`
+	Database struct {
+		Host    string   `ini:"host"`
+		Port    int      `ini:"port,omitempty"`
+		Options []string `ini:"options"`
+	} `ini:"database"`
+	Server struct {
+		HTTP struct {
+			Port int `ini:"port"`
+		} `ini:"http"`
+	} `ini:"server"`
+}
+
+func TestToINIMapWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Sections and comments",
+			input: "; global\nname = api\n\n[database] ; main\nhost = localhost ; primary\nport: 5432\nport = 5433\n" +
+				"# other\n[server.http]\r\nport=80",
+			want: map[string]string{
+				"name": "api", "database.host": "localhost", "database.port": "5433", "server.http.port": "80",
+			},
+		},
+		{
+			name:  "Quotes",
+			input: "[q]\ndouble = \"a ; b \\\"c\\\"\\n\" ; comment\nsingle = ' x\\n '\nempty =",
+			want:  map[string]string{"q.double": "a ; b \"c\"\n", "q.single": ` x\n `, "q.empty": ""},
+		},
+		{name: "Unterminated section", input: "[db\nhost=x", wantErr: true},
+		{name: "Empty section", input: "[ ]", wantErr: true},
+		{name: "Missing separator", input: "host", wantErr: true},
+		{name: "Unterminated quote", input: "a = \"x", wantErr: true},
+		{name: "Text after quote", input: "a = \"x\" y", wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToINIMapWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToINIMapWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToINIMapWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDestFromINIWithErr(t *testing.T) {
+	var got iniTestConfig
+	input := "name = api\n[database]\nhost = localhost\nport = 5432\noptions = ssl, pool\n[server.http]\nport = 80"
+	if err := ToDestFromINIWithErr(input, &got); err != nil {
+		t.Fatalf("ToDestFromINIWithErr() error = %v", err)
+	}
+	if got.Name != "api" || got.Database.Host != "localhost" || got.Database.Port != 5432 ||
+		!reflect.DeepEqual(got.Database.Options, []string{"ssl", "pool"}) || got.Server.HTTP.Port != 80 {
+		t.Errorf("ToDestFromINIWithErr() = %+v", got)
+	}
+
+	var tree map[string]any
+	if err := ToDestFromINIWithErr("a = 1\n[s]\nb = 2", &tree); err != nil {
+		t.Fatalf("ToDestFromINIWithErr() error = %v", err)
+	}
+	if want := map[string]any{"a": "1", "s": map[string]any{"b": "2"}}; !reflect.DeepEqual(tree, want) {
+		t.Errorf("ToDestFromINIWithErr() = %v, want %v", tree, want)
+	}
+
+	if err := ToDestFromINIWithErr("[database]\nport = x", &got); err == nil {
+		t.Error("ToDestFromINIWithErr() error = nil, want an error for an invalid number")
+	}
+}
+
+func TestToINIStringWithErr(t *testing.T) {
+	var config iniTestConfig
+	config.Name = "my api"
+	config.Database.Host = "db;1"
+	config.Database.Options = []string{"ssl", "pool"}
+	config.Server.HTTP.Port = 80
+
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Struct",
+			input: config,
+			want: "name = my api\n\n[database]\nhost = \"db;1\"\noptions = ssl,pool\n\n" +
+				"[server.http]\nport = 80\n",
+		},
+		{
+			name:  "Map without globals",
+			input: map[string]any{"b": map[string]any{"x": " y"}, "a": map[string]any{"x": ""}},
+			want:  "[a]\nx = \n\n[b]\nx = \" y\"\n",
+		},
+		{name: "Invalid key", input: map[string]any{"a=b": 1}, wantErr: true},
+		{name: "Unsupported", input: 1, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToINIStringWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToINIStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToINIStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -67,6 +67,8 @@ func ToInt(a any) int {
 // In the case of Interface and Pointer, if the value is nil, it returns an error, otherwise,
 // the function recursively calls itself with the contained value in Interface and Pointer.
 //
+// A time.Time is converted to its epoch in DefaultEpochUnit, milliseconds.
+//
 // If the kind does not match any cases, a default case returns an error indicating an unsupported type.
 //
 // Parameters:
@@ -116,6 +118,11 @@ func ToIntWithErr(a any) (int, error) {
 			return strconv.Atoi(string(reflectValue.Bytes()))
 		}
 		return 0, fmt.Errorf("error convert to int, unsupported type %s", reflectValue.Kind().String())
+	case reflect.Struct:
+		if t, ok := timeIfPresent(reflectValue); ok {
+			return int(epochFromTime(t, DefaultEpochUnit)), nil
+		}
+		return 0, fmt.Errorf("error convert to int, unsupported type %s", reflectValue.Kind().String())
 	case reflect.Interface, reflect.Pointer:
 		if reflectValue.IsNil() {
 			return 0, errors.New("error convert to int, it is null")
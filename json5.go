@@ -0,0 +1,532 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// json5MaxDepth limits the nesting of lenient JSON documents, so that malicious input cannot exhaust the stack.
+const json5MaxDepth = 1000
+
+// JSONSyntaxError is returned for invalid lenient JSON documents, locating the problem.
+type JSONSyntaxError struct {
+	// Line is the line of the problem, starting at 1.
+	Line int
+	// Column is the column of the problem in characters, starting at 1.
+	Column int
+	// Msg describes the problem.
+	Msg string
+}
+
+// Error returns the line, column and description of the problem.
+func (e *JSONSyntaxError) Error() string {
+	return fmt.Sprintf("error convert from JSON, line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ToStrictJSON converts the given JSON5 to strict JSON, panicking if the conversion fails.
+// See ToStrictJSONWithErr.
+func ToStrictJSON(a any) string {
+	s, err := ToStrictJSONWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToStrictJSONWithErr converts the given value to a string using ToStringWithErr, parses it as JSON5 and returns
+// the equivalent strict JSON, compacted.
+//
+// Besides strict JSON, the following is accepted:
+//   - Comments, either // to the end of the line or /* */.
+//   - Trailing commas in objects and arrays.
+//   - Strings in single quotes, the escapes \', \v, \0 and \xFF, and lines continued by a backslash.
+//   - Object keys written as identifiers, e.g. {name: 'x'}.
+//   - Numbers with a leading "+", a leading or trailing decimal point, e.g. .5 and 5., and hexadecimal integers,
+//     e.g. 0xFF.
+//
+// Infinity and NaN are rejected, since strict JSON cannot represent them.
+//
+// Parameters:
+//   - a: The JSON5 document, usually a string or a byte slice.
+//
+// Returns:
+//   - string: The strict JSON equivalent of the document.
+//   - error: A *JSONSyntaxError is returned if the document is invalid.
+//
+// Example:
+//
+//	s, _ := ToStrictJSONWithErr("{\n  // retries\n  max: 0x10, ratio: .5, name: 'api',\n}")
+//	fmt.Println(s) // {"max":16,"ratio":0.5,"name":"api"}
+func ToStrictJSONWithErr(a any) (string, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	bs, err := toStrictJSON([]byte(s))
+	return string(bs), err
+}
+
+// ToDestFromJSON5 decodes the given JSON5 into dest, panicking if it fails. See ToDestFromJSON5WithErr.
+func ToDestFromJSON5(a, dest any) {
+	if err := ToDestFromJSON5WithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// ToDestFromJSON5WithErr converts the given value to strict JSON using ToStrictJSONWithErr and decodes it into dest
+// with encoding/json, so configuration files written as JSON5, with comments, trailing commas and single quotes,
+// fill structs, maps and slices as JSON does.
+//
+// Parameters:
+//   - a: The JSON5 document, usually a string or a byte slice.
+//   - dest: The pointer to be filled.
+//
+// Returns:
+//   - error: A *JSONSyntaxError is returned if the document is invalid, and another error in case of failure to
+//     decode it into dest.
+//
+// Example:
+//
+//	var config struct {
+//		Hosts []string `json:"hosts"`
+//	}
+//	err := ToDestFromJSON5WithErr("{hosts: ['a', 'b',], // primary first\n}", &config)
+//	fmt.Println(config.Hosts, err) // [a b] <nil>
+func ToDestFromJSON5WithErr(a, dest any) error {
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer {
+		return errors.New("dest is not a pointer")
+	} else if reflectDest.IsNil() {
+		return errors.New("dest is nil")
+	}
+
+	s, err := ToStrictJSONWithErr(a)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(s), dest)
+}
+
+// isTextSource reports whether the given value is a string or a byte slice, directly or through pointers.
+func isTextSource(a any) bool {
+	reflectValue := reflect.ValueOf(indirectValue(a))
+	return reflectValue.Kind() == reflect.String ||
+		reflectValue.Kind() == reflect.Slice && reflectValue.Type().Elem().Kind() == reflect.Uint8
+}
+
+// isJSONDocumentStart reports whether the given bytes start with an object or an array, ignoring blanks and
+// comments.
+func isJSONDocumentStart(bs []byte) bool {
+	p := &json5Parser{src: StripBOM(bs)}
+	if p.skipSpace() != nil {
+		return false
+	}
+	return p.pos < len(p.src) && (p.src[p.pos] == '{' || p.src[p.pos] == '[')
+}
+
+func toStrictJSON(bs []byte) ([]byte, error) {
+	p := &json5Parser{src: StripBOM(bs)}
+	if err := p.skipSpace(); err != nil {
+		return nil, err
+	} else if err = p.value(0); err != nil {
+		return nil, err
+	} else if err = p.skipSpace(); err != nil {
+		return nil, err
+	} else if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q after value", p.peekRune())
+	}
+	return p.out, nil
+}
+
+type json5Parser struct {
+	src []byte
+	pos int
+	out []byte
+}
+
+func (p *json5Parser) errorf(format string, args ...any) error {
+	line, column := 1, 1
+	for _, r := range string(p.src[:min(p.pos, len(p.src))]) {
+		if r == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return &JSONSyntaxError{Line: line, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *json5Parser) peekRune() rune {
+	r, _ := utf8.DecodeRune(p.src[p.pos:])
+	return r
+}
+
+func (p *json5Parser) skipSpace() error {
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRune(p.src[p.pos:])
+		switch {
+		case unicode.IsSpace(r) || r == '\ufeff':
+			p.pos += size
+		case bytes.HasPrefix(p.src[p.pos:], []byte("//")):
+			end := bytes.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				p.pos = len(p.src)
+			} else {
+				p.pos += end + 1
+			}
+		case bytes.HasPrefix(p.src[p.pos:], []byte("/*")):
+			end := bytes.Index(p.src[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				return p.errorf("unterminated comment")
+			}
+			p.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *json5Parser) value(depth int) error {
+	if depth > json5MaxDepth {
+		return p.errorf("maximum nesting depth exceeded")
+	} else if p.pos >= len(p.src) {
+		return p.errorf("unexpected end of input")
+	}
+
+	switch c := p.src[p.pos]; {
+	case c == '{':
+		return p.container(depth, '}')
+	case c == '[':
+		return p.container(depth, ']')
+	case c == '"' || c == '\'':
+		s, err := p.string()
+		if err != nil {
+			return err
+		}
+		p.out = appendJSONString(p.out, s)
+		return nil
+	case c == '-' || c == '+' || c == '.' || c >= '0' && c <= '9':
+		return p.number()
+	}
+
+	start := p.pos
+	name := p.identifier()
+	switch name {
+	case "true", "false", "null":
+		p.out = append(p.out, name...)
+		return nil
+	case "Infinity", "NaN":
+		p.pos = start
+		return p.errorf("%s is not supported in JSON", name)
+	}
+	p.pos = start
+	if p.pos >= len(p.src) {
+		return p.errorf("unexpected end of input")
+	}
+	return p.errorf("unexpected %q", p.peekRune())
+}
+
+// container parses an object or an array, whose closing character is given.
+func (p *json5Parser) container(depth int, closing byte) error {
+	p.out = append(p.out, p.src[p.pos])
+	p.pos++
+
+	for first := true; ; first = false {
+		if err := p.skipSpace(); err != nil {
+			return err
+		} else if p.pos >= len(p.src) {
+			return p.errorf("unexpected end of input, expected %q", closing)
+		} else if p.src[p.pos] == closing {
+			p.out = append(p.out, closing)
+			p.pos++
+			return nil
+		} else if !first {
+			p.out = append(p.out, ',')
+		}
+
+		if closing == '}' {
+			if err := p.key(); err != nil {
+				return err
+			} else if err = p.skipSpace(); err != nil {
+				return err
+			} else if p.pos >= len(p.src) || p.src[p.pos] != ':' {
+				return p.errorf("expected ':' after object key")
+			}
+			p.out = append(p.out, ':')
+			p.pos++
+			if err := p.skipSpace(); err != nil {
+				return err
+			}
+		}
+		if err := p.value(depth + 1); err != nil {
+			return err
+		} else if err = p.skipSpace(); err != nil {
+			return err
+		}
+
+		if p.pos >= len(p.src) {
+			return p.errorf("unexpected end of input, expected %q", closing)
+		} else if p.src[p.pos] == ',' {
+			p.pos++
+		} else if p.src[p.pos] != closing {
+			return p.errorf("expected ',' or %q", closing)
+		}
+	}
+}
+
+func (p *json5Parser) key() error {
+	if c := p.src[p.pos]; c == '"' || c == '\'' {
+		s, err := p.string()
+		if err != nil {
+			return err
+		}
+		p.out = appendJSONString(p.out, s)
+		return nil
+	}
+
+	name := p.identifier()
+	if name == "" {
+		return p.errorf("unexpected %q, expected an object key", p.peekRune())
+	}
+	p.out = appendJSONString(p.out, name)
+	return nil
+}
+
+// identifier reads an ECMAScript identifier, without escapes, returning an empty string if there is none.
+func (p *json5Parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRune(p.src[p.pos:])
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (p.pos == start || !unicode.IsDigit(r)) {
+			break
+		}
+		p.pos += size
+	}
+	return string(p.src[start:p.pos])
+}
+
+func (p *json5Parser) string() (string, error) {
+	quote := p.src[p.pos]
+	start := p.pos
+	p.pos++
+
+	var builder strings.Builder
+	for {
+		if p.pos >= len(p.src) {
+			p.pos = start
+			return "", p.errorf("unterminated string")
+		}
+
+		r, size := utf8.DecodeRune(p.src[p.pos:])
+		switch {
+		case r == rune(quote):
+			p.pos++
+			return builder.String(), nil
+		case r == '\n' || r == '\r':
+			return "", p.errorf("unescaped line break in string")
+		case r != '\\':
+			builder.WriteRune(r)
+			p.pos += size
+			continue
+		}
+
+		p.pos++
+		if p.pos >= len(p.src) {
+			continue
+		}
+		escape := p.src[p.pos]
+		p.pos++
+		switch escape {
+		case 'b':
+			builder.WriteByte('\b')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'v':
+			builder.WriteByte('\v')
+		case '0':
+			builder.WriteByte(0)
+		case '\n':
+		case '\r':
+			if p.pos < len(p.src) && p.src[p.pos] == '\n' {
+				p.pos++
+			}
+		case 'x', 'u':
+			size := 2
+			if escape == 'u' {
+				size = 4
+			}
+			if p.pos+size > len(p.src) {
+				p.pos -= 2
+				return "", p.errorf("malformed \\%c escape", escape)
+			}
+			u, err := strconv.ParseUint(string(p.src[p.pos:p.pos+size]), 16, 16)
+			if err != nil {
+				p.pos -= 2
+				return "", p.errorf("malformed \\%c escape", escape)
+			}
+			p.pos += size
+			r := rune(u)
+			if utf16.IsSurrogate(r) && bytes.HasPrefix(p.src[p.pos:], []byte(`\u`)) && p.pos+6 <= len(p.src) {
+				if low, err := strconv.ParseUint(string(p.src[p.pos+2:p.pos+6]), 16, 16); err == nil {
+					if decoded := utf16.DecodeRune(r, rune(low)); decoded != unicode.ReplacementChar {
+						r = decoded
+						p.pos += 6
+					}
+				}
+			}
+			builder.WriteRune(r)
+		default:
+			p.pos--
+			r, size := utf8.DecodeRune(p.src[p.pos:])
+			if r == '\u2028' || r == '\u2029' {
+				p.pos += size
+				continue
+			}
+			builder.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+func (p *json5Parser) number() error {
+	start := p.pos
+	negative := false
+	if c := p.src[p.pos]; c == '+' || c == '-' {
+		negative = c == '-'
+		p.pos++
+	}
+
+	if name := p.identifier(); name == "Infinity" || name == "NaN" {
+		p.pos = start
+		return p.errorf("%s is not supported in JSON", name)
+	} else if name != "" {
+		p.pos = start
+		return p.errorf("invalid number")
+	}
+
+	if bytes.HasPrefix(p.src[p.pos:], []byte("0x")) || bytes.HasPrefix(p.src[p.pos:], []byte("0X")) {
+		p.pos += 2
+		hexStart := p.pos
+		for p.pos < len(p.src) && isHexDigit(p.src[p.pos]) {
+			p.pos++
+		}
+		x, ok := new(big.Int).SetString(string(p.src[hexStart:p.pos]), 16)
+		if !ok {
+			p.pos = start
+			return p.errorf("invalid hexadecimal number")
+		}
+		if negative && x.Sign() != 0 {
+			x.Neg(x)
+		}
+		p.out = x.Append(p.out, 10)
+		return p.endNumber(start)
+	}
+
+	integer := p.digits()
+	fraction := ""
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.pos++
+		fraction = p.digits()
+	}
+	if integer == "" && fraction == "" || len(integer) > 1 && integer[0] == '0' {
+		p.pos = start
+		return p.errorf("invalid number")
+	}
+
+	exponent := ""
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.pos++
+		sign := ""
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			sign = string(p.src[p.pos])
+			p.pos++
+		}
+		digits := p.digits()
+		if digits == "" {
+			p.pos = start
+			return p.errorf("invalid number exponent")
+		}
+		exponent = "e" + sign + digits
+	}
+
+	if negative {
+		p.out = append(p.out, '-')
+	}
+	if integer == "" {
+		integer = "0"
+	}
+	p.out = append(p.out, integer...)
+	if fraction != "" {
+		p.out = append(append(p.out, '.'), fraction...)
+	}
+	p.out = append(p.out, exponent...)
+	return p.endNumber(start)
+}
+
+func (p *json5Parser) digits() string {
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+// endNumber fails when a number is immediately followed by a letter or digit, e.g. 12px.
+func (p *json5Parser) endNumber(start int) error {
+	if p.pos < len(p.src) {
+		if r := p.peekRune(); r == '_' || r == '$' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			p.pos = start
+			return p.errorf("invalid number")
+		}
+	}
+	return nil
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// appendJSONString appends s as a JSON string, escaping only quotes, backslashes and control characters, with the
+// short escapes where JSON has them.
+func appendJSONString(bs []byte, s string) []byte {
+	bs = append(bs, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			bs = append(bs, '\\', c)
+		case '\b':
+			bs = append(bs, '\\', 'b')
+		case '\f':
+			bs = append(bs, '\\', 'f')
+		case '\n':
+			bs = append(bs, '\\', 'n')
+		case '\r':
+			bs = append(bs, '\\', 'r')
+		case '\t':
+			bs = append(bs, '\\', 't')
+		default:
+			if c < 0x20 {
+				bs = append(bs, fmt.Sprintf(`\u%04x`, c)...)
+			} else {
+				bs = append(bs, c)
+			}
+		}
+	}
+	return append(bs, '"')
+}
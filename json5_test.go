@@ -0,0 +1,161 @@
+package converter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestToStrictJSONWithErr(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"Strict", `{"a": [1, 2.5e-3, true, null]}`, `{"a":[1,2.5e-3,true,null]}`},
+		{"Comments", "// head\n{/* inline */ \"a\": 1 // tail\n}", `{"a":1}`},
+		{"Trailing commas", "{a: [1, 2,],}", `{"a":[1,2]}`},
+		{"Identifier keys", "{_id: 1, $ref: 2, café: 3}", `{"_id":1,"$ref":2,"café":3}`},
+		{"Single quotes", `{'a': 'it\'s "ok"'}`, `{"a":"it's \"ok\""}`},
+		{"Escapes", `'\x41\v\0é😀\/'`, `"A\u000b\u0000é😀/"`},
+		{"Line continuation", "'a\\\nb\\\r\nc'", `"abc"`},
+		{"Control characters", []byte("\"\t\x01\""), `"\t\u0001"`},
+		{"Hexadecimal", "[0xFF, -0x10, 0x10000000000000000]", "[255,-16,18446744073709551616]"},
+		{"Decimal points", "[.5, 5., -.5e2, +1]", "[0.5,5,-0.5e2,1]"},
+		{"Byte order mark", "\ufeff[1]", "[1]"},
+		{"Scalar", " 'x' ", `"x"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToStrictJSONWithErr(tc.input)
+			if err != nil {
+				t.Fatalf("ToStrictJSONWithErr() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ToStrictJSONWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToStrictJSONWithErrInvalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  JSONSyntaxError
+	}{
+		{"Empty", "", JSONSyntaxError{1, 1, "unexpected end of input"}},
+		{"Missing comma", "{\n  a: 1\n  b: 2\n}", JSONSyntaxError{3, 3, `expected ',' or '}'`}},
+		{"Missing colon", "{a 1}", JSONSyntaxError{1, 4, "expected ':' after object key"}},
+		{"Unterminated object", "[{a: 1}", JSONSyntaxError{1, 8, `unexpected end of input, expected ']'`}},
+		{"Unterminated string", "{a: 'café", JSONSyntaxError{1, 5, "unterminated string"}},
+		{"Unterminated comment", "[1 /* x", JSONSyntaxError{1, 4, "unterminated comment"}},
+		{"Line break in string", "'a\nb'", JSONSyntaxError{1, 3, "unescaped line break in string"}},
+		{"Malformed escape", `'\u12'`, JSONSyntaxError{1, 2, `malformed \u escape`}},
+		{"Infinity", "[-Infinity]", JSONSyntaxError{1, 2, "Infinity is not supported in JSON"}},
+		{"NaN", "{a: NaN}", JSONSyntaxError{1, 5, "NaN is not supported in JSON"}},
+		{"Leading zero", "[01]", JSONSyntaxError{1, 2, "invalid number"}},
+		{"Unit suffix", "[12px]", JSONSyntaxError{1, 2, "invalid number"}},
+		{"Missing exponent", "[1e]", JSONSyntaxError{1, 2, "invalid number exponent"}},
+		{"Unknown literal", "[undefined]", JSONSyntaxError{1, 2, `unexpected 'u'`}},
+		{"Missing key", "{,}", JSONSyntaxError{1, 2, `unexpected ',', expected an object key`}},
+		{"Trailing content", "{} {}", JSONSyntaxError{1, 4, `unexpected '{' after value`}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ToStrictJSONWithErr(tc.input)
+			var syntaxErr *JSONSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ToStrictJSONWithErr() error = %v, want a *JSONSyntaxError", err)
+			}
+			if *syntaxErr != tc.want {
+				t.Errorf("ToStrictJSONWithErr() error = %+v, want %+v", *syntaxErr, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDestFromJSON5WithErr(t *testing.T) {
+	type config struct {
+		Name  string   `json:"name"`
+		Hosts []string `json:"hosts"`
+		Port  int      `json:"port"`
+	}
+	input := `{
+  // service
+  name: 'api',
+  hosts: ['a', 'b',],
+  port: 0x1F90,
+}`
+
+	var got config
+	if err := ToDestWithErr(input, &got); err == nil {
+		t.Error("ToDestWithErr() error = nil, want an error for JSON5")
+	}
+
+	want := config{Name: "api", Hosts: []string{"a", "b"}, Port: 8080}
+	for _, a := range []any{input, []byte(input), ToPointer(input)} {
+		got = config{}
+		if err := ToDestFromJSON5WithErr(a, &got); err != nil {
+			t.Fatalf("ToDestFromJSON5WithErr() error = %v", err)
+		} else if !reflect.DeepEqual(got, want) {
+			t.Errorf("ToDestFromJSON5WithErr() = %+v, want %+v", got, want)
+		}
+	}
+
+	var tree map[string]any
+	err := ToDestFromJSON5WithErr("{\n  a: 1,\n  b: [1 2],\n}", &tree)
+	if err == nil || err.Error() != "error convert from JSON, line 3, column 9: expected ',' or ']'" {
+		t.Errorf("ToDestFromJSON5WithErr() error = %v", err)
+	}
+
+	var pairs []string
+	ToDestFromJSON5("['x=y']", &pairs)
+	if !reflect.DeepEqual(pairs, []string{"x=y"}) {
+		t.Errorf("ToDestFromJSON5() = %v, want %v", pairs, []string{"x=y"})
+	}
+
+	var items []int
+	if err = ToDestFromJSON5WithErr("{a: 1}", &items); err == nil {
+		t.Error("ToDestFromJSON5WithErr() error = nil, want an error for an object into a slice")
+	}
+	if err = ToDestFromJSON5WithErr("[1]", items); err == nil {
+		t.Error("ToDestFromJSON5WithErr() error = nil, want an error for a non pointer dest")
+	}
+}
+
+func TestToCompactStringLenientWithErr(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"JSON5 array", "/* list */ [1, 'two',\n]", `[1,"two"]`},
+		{"JSON5 bytes", []byte("{a: 1,   }"), `{"a":1}`},
+		{"JSON", `{ "a" : 1 }`, `{"a":1}`},
+		{"Text", "Hello,   World!  ", "Hello, World!"},
+		{"Invalid JSON5", "[WARN]   disk   full", "[WARN] disk full"},
+		{"Map", map[string]int{"a": 1}, `{"a":1}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToCompactStringLenientWithErr(tc.input)
+			if err != nil {
+				t.Fatalf("ToCompactStringLenientWithErr() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ToCompactStringLenientWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if s := ToCompactString("{a: 1,   }"); s != "{a: 1, }" {
+		t.Errorf("ToCompactString() = %v, want the text compacted", s)
+	}
+	if s := ToCompactStringLenient("[1,  2"); s != "[1, 2" {
+		t.Errorf("ToCompactStringLenient() = %v, want the text compacted", s)
+	}
+}
@@ -0,0 +1,237 @@
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale holds the month and weekday names of a language, used by ToTimeWithErr to parse dates such as
+// "17 de outubro de 2026" and by ToLocalizedStringWithErr to format them.
+//
+// Names are matched case and accent insensitively when parsing, and rendered exactly as written when formatting.
+type Locale struct {
+	// Name identifies the locale, e.g. "pt-BR".
+	Name string
+	// Months holds the full month names, starting with January.
+	Months [12]string
+	// ShortMonths holds the abbreviated month names, starting with January.
+	ShortMonths [12]string
+	// Weekdays holds the full weekday names, starting with Sunday.
+	Weekdays [7]string
+	// ShortWeekdays holds the abbreviated weekday names, starting with Sunday.
+	ShortWeekdays [7]string
+	// Ordinal renders a day of the month as an ordinal, e.g. "17th". When nil, the plain number is used.
+	Ordinal func(day int) string
+	// Fillers lists words that are ignored when parsing, e.g. "de" in "17 de outubro de 2026".
+	Fillers []string
+}
+
+var (
+	localesMutex sync.RWMutex
+	locales      []Locale
+
+	localeOrdinalRegex = regexp.MustCompile(`(\d+)(?:st\b|nd\b|rd\b|th\b|o\b|º|ª|°)`)
+	localeLayouts      = []string{"2 Jan 2006", "Jan 2 2006", "2 Jan 2006 15:04", "Jan 2 2006 15:04",
+		"2 Jan 2006 15:04:05", "Jan 2 2006 15:04:05", "2006 Jan 2", "Jan 2006", "2 Jan"}
+)
+
+func init() {
+	RegisterLocale(Locale{
+		Name: "en",
+		Months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+			"October", "November", "December"},
+		ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Ordinal:       englishOrdinal,
+		Fillers:       []string{"of", "the", "at"},
+	})
+	RegisterLocale(Locale{
+		Name: "pt-BR",
+		Months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro",
+			"outubro", "novembro", "dezembro"},
+		ShortMonths: [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		Weekdays: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira",
+			"sábado"},
+		ShortWeekdays: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		Ordinal: func(day int) string {
+			if day == 1 {
+				return "1º"
+			}
+			return strconv.Itoa(day)
+		},
+		Fillers: []string{"de", "do", "às", "as"},
+	})
+}
+
+// RegisterLocale adds a locale, or replaces the one with the same name, making it available to ToTimeWithErr,
+// ToLocalizedStringWithErr. The "en" and "pt-BR" locales are registered by default.
+func RegisterLocale(locale Locale) {
+	localesMutex.Lock()
+	defer localesMutex.Unlock()
+
+	for i, registered := range locales {
+		if strings.EqualFold(registered.Name, locale.Name) {
+			locales[i] = locale
+			return
+		}
+	}
+	locales = append(locales, locale)
+}
+
+// LookupLocale returns the registered locale with the given name, compared case insensitively.
+func LookupLocale(name string) (Locale, bool) {
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+
+	for _, locale := range locales {
+		if strings.EqualFold(locale.Name, name) {
+			return locale, true
+		}
+	}
+	return Locale{}, false
+}
+
+// ToLocalizedString converts the given value to a time.Time and formats it in the given locale, panicking if the
+// conversion fails. See ToLocalizedStringWithErr.
+func ToLocalizedString(a any, layout, locale string) string {
+	s, err := ToLocalizedStringWithErr(a, layout, locale)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToLocalizedStringWithErr converts the given value to a time.Time using ToTimeWithErr and formats it with the
+// given layout, rendering month and weekday names in the given locale.
+//
+// The layout follows the time package, where "January", "Jan", "Monday" and "Mon" are replaced by the locale names.
+// Additionally, "2nd" renders the day of the month as an ordinal, e.g. "17th" in English.
+//
+// Example:
+//
+//	t := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+//	s, _ := ToLocalizedStringWithErr(t, "Mon, 2 Jan 2006", "pt-BR")
+//	fmt.Println(s) // sáb, 17 out 2026
+//	s, _ = ToLocalizedStringWithErr(t, "January 2nd, 2006", "en")
+//	fmt.Println(s) // October 17th, 2026
+func ToLocalizedStringWithErr(a any, layout, locale string) (string, error) {
+	t, err := ToTimeWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	l, ok := LookupLocale(locale)
+	if !ok {
+		return "", fmt.Errorf("unknown locale: %s", locale)
+	}
+	return formatTimeInLocale(t, layout, l), nil
+}
+
+func formatTimeInLocale(t time.Time, layout string, locale Locale) string {
+	var sb strings.Builder
+	for layout != "" {
+		name, token := "", ""
+		switch {
+		case strings.HasPrefix(layout, "January"):
+			name, token = locale.Months[t.Month()-1], "January"
+		case strings.HasPrefix(layout, "Jan"):
+			name, token = locale.ShortMonths[t.Month()-1], "Jan"
+		case strings.HasPrefix(layout, "Monday"):
+			name, token = locale.Weekdays[t.Weekday()], "Monday"
+		case strings.HasPrefix(layout, "Mon"):
+			name, token = locale.ShortWeekdays[t.Weekday()], "Mon"
+		case strings.HasPrefix(layout, "2nd"):
+			name, token = strconv.Itoa(t.Day()), "2nd"
+			if locale.Ordinal != nil {
+				name = locale.Ordinal(t.Day())
+			}
+		}
+		if token != "" {
+			sb.WriteString(name)
+			layout = layout[len(token):]
+			continue
+		}
+
+		end := 1
+		for end < len(layout) && !hasLocaleTokenPrefix(layout[end:]) {
+			end++
+		}
+		sb.WriteString(t.Format(layout[:end]))
+		layout = layout[end:]
+	}
+	return sb.String()
+}
+
+func hasLocaleTokenPrefix(s string) bool {
+	return strings.HasPrefix(s, "Jan") || strings.HasPrefix(s, "Mon") || strings.HasPrefix(s, "2nd")
+}
+
+func parseLocalizedTime(s string) (time.Time, bool) {
+	text := localeOrdinalRegex.ReplaceAllString(foldAccents(strings.ToLower(s)), "$1")
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '.' || r == '/' || r == '\t'
+	})
+
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+
+	for _, locale := range locales {
+		if normalized, ok := normalizeLocalizedFields(fields, locale); ok {
+			for _, layout := range localeLayouts {
+				if t, err := time.Parse(layout, normalized); err == nil {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func normalizeLocalizedFields(fields []string, locale Locale) (string, bool) {
+	var normalized []string
+	hasMonth := false
+
+fields:
+	for _, field := range fields {
+		for _, filler := range locale.Fillers {
+			if field == foldAccents(strings.ToLower(filler)) {
+				continue fields
+			}
+		}
+		for i := 0; i < 12; i++ {
+			if field == foldAccents(strings.ToLower(locale.Months[i])) ||
+				field == foldAccents(strings.ToLower(locale.ShortMonths[i])) {
+				normalized = append(normalized, time.Month(i + 1).String()[:3])
+				hasMonth = true
+				continue fields
+			}
+		}
+		for i := 0; i < 7; i++ {
+			if field == foldAccents(strings.ToLower(locale.Weekdays[i])) ||
+				field == foldAccents(strings.ToLower(locale.ShortWeekdays[i])) {
+				continue fields
+			}
+		}
+		normalized = append(normalized, field)
+	}
+
+	return strings.Join(normalized, " "), hasMonth
+}
+
+func englishOrdinal(day int) string {
+	suffix := "th"
+	switch {
+	case day%100 >= 11 && day%100 <= 13:
+	case day%10 == 1:
+		suffix = "st"
+	case day%10 == 2:
+		suffix = "nd"
+	case day%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(day) + suffix
+}
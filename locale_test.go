@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToTimeWithErrLocalized(t *testing.T) {
+	want := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"Portuguese long", "17 de outubro de 2026", want},
+		{"Portuguese weekday", "seg, 17 out 2026", want},
+		{"Portuguese upper case", "17 DE OUTUBRO DE 2026", want},
+		{"Portuguese accents", "1º de março de 2026", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"Portuguese without accents", "1o de marco de 2026", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"Portuguese with time", "17 de outubro de 2026 às 10:30", want.Add(10*time.Hour + 30*time.Minute)},
+		{"English ordinal", "October 17th, 2026", want},
+		{"English weekday", "Saturday, 17 October 2026", want},
+		{"English lower case", "oct 17 2026", want},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToTimeWithErr(tc.input)
+			if err != nil {
+				t.Fatalf("ToTimeWithErr() error = %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("ToTimeWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := ToTimeWithErr("17 de nada de 2026"); err == nil {
+		t.Error("ToTimeWithErr() expected error")
+	}
+}
+
+func TestToLocalizedStringWithErr(t *testing.T) {
+	value := time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		layout  string
+		locale  string
+		want    string
+		wantErr bool
+	}{
+		{"Portuguese long", "2 de January de 2006", "pt-BR", "17 de outubro de 2026", false},
+		{"Portuguese short", "Mon, 2 Jan 2006", "pt-BR", "sáb, 17 out 2026", false},
+		{"Portuguese weekday", "Monday, 02/01/2006 15:04", "pt-BR", "sábado, 17/10/2026 10:30", false},
+		{"English ordinal", "January 2nd, 2006", "en", "October 17th, 2026", false},
+		{"Unknown locale", "Jan", "xx", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToLocalizedStringWithErr(value, tc.layout, tc.locale)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToLocalizedStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToLocalizedStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if got := englishOrdinal(1) + englishOrdinal(2) + englishOrdinal(3) + englishOrdinal(11) + englishOrdinal(22); got != "1st2nd3rd11th22nd" {
+		t.Errorf("englishOrdinal() = %q", got)
+	}
+}
+
+func TestToStringIgnoresLocales(t *testing.T) {
+	value := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	if got := ToTimeString(value, "2 January 2006"); got != "17 October 2026" {
+		t.Errorf("ToTimeString() = %q, want %q", got, "17 October 2026")
+	}
+
+	got := ToLocalizedString(value, "2 de January de 2006", "pt-BR")
+	if want := "17 de outubro de 2026"; got != want {
+		t.Errorf("ToLocalizedString() = %q, want %q", got, want)
+	}
+	if back := ToTime(got); !back.Equal(value) {
+		t.Errorf("ToTime() = %v, want %v", back, value)
+	}
+}
@@ -0,0 +1,245 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// logfmtTags are the struct tags that name fields in logfmt lines.
+var logfmtTags = []string{"logfmt", "json"}
+
+// ToLogfmt converts the given struct or map to a logfmt line, panicking if the conversion fails.
+// See ToLogfmtWithErr.
+func ToLogfmt(a any) string {
+	s, err := ToLogfmtWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToLogfmtWithErr converts the given struct or map, or a pointer to one, to a logfmt line such as
+// `level=info msg="user created" user.id=42`, without a trailing line break.
+//
+// Fields are named by the `logfmt` tag, then the `json` tag, then the field name, and tagging a field "-" skips
+// it, while "omitempty" leaves out empty values. Keys follow the order of the struct fields, or the sorted order
+// of the map keys, so the same value always renders the same line. Nested structs and maps are flattened with
+// keys joined by ".", and slices of scalars are joined by ",". Values are converted with ToStringWithErr, and
+// quoted with the escapes of Go strings when they hold spaces, "=", quotes or control characters. Empty values are
+// written as `key=`.
+//
+// Parameters:
+//   - a: The struct or map to be converted.
+//
+// Returns:
+//   - string: The logfmt line.
+//   - error: An error is returned if the value is not a struct or map, if a key is invalid or in case of
+//     failure to convert a value.
+//
+// Example:
+//
+//	type Request struct {
+//		Method   string        `logfmt:"method"`
+//		Path     string        `logfmt:"path"`
+//		Status   int           `logfmt:"status"`
+//		Duration time.Duration `logfmt:"took"`
+//		Error    string        `logfmt:"err,omitempty"`
+//	}
+//	s, _ := ToLogfmtWithErr(Request{Method: "GET", Path: "/a b", Status: 200, Duration: 1500 * time.Millisecond})
+//	fmt.Println(s) // method=GET path="/a b" status=200 took=1.5s
+func ToLogfmtWithErr(a any) (string, error) {
+	entries, err := weakFlattenStructOrMap(a, "logfmt", logfmtTags, ",")
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	for i, entry := range entries {
+		key := strings.Join(entry.path, ".")
+		if !isLogfmtKey(key) {
+			return "", fmt.Errorf("error convert to logfmt, invalid key %q", key)
+		}
+		if i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(key)
+		builder.WriteByte('=')
+		builder.WriteString(quoteLogfmt(entry.value))
+	}
+	return builder.String(), nil
+}
+
+// ToLogfmtMap parses the given logfmt text, panicking if parsing fails. See ToLogfmtMapWithErr.
+func ToLogfmtMap(a any) map[string]string {
+	m, err := ToLogfmtMapWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToLogfmtMapWithErr converts the given value to a string using ToStringWithErr and parses it as logfmt,
+// returning its pairs.
+//
+// Pairs are separated by blanks and written as key=value, key="quoted value" or a bare key, whose value is
+// empty. Quoted values accept the escapes of Go strings, such as \", \\, \n and \u00e9. When the text has many
+// lines, the pairs of all of them are returned, with later keys replacing earlier ones, so records are usually
+// parsed one line at a time.
+//
+// Parameters:
+//   - a: The logfmt text, usually a string or a byte slice.
+//
+// Returns:
+//   - map[string]string: The pairs of the text.
+//   - error: An error, with the line and column, is returned in case of failure to parse.
+//
+// Example:
+//
+//	m, _ := ToLogfmtMapWithErr(`level=warn msg="disk \"/data\" almost full" used=91.5 retry`)
+//	fmt.Println(m["msg"], m["used"], m["retry"] == "") // disk "/data" almost full 91.5 true
+func ToLogfmtMapWithErr(a any) (map[string]string, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	lines := strings.Split(string(StripBOM([]byte(s))), "\n")
+	for i, line := range lines {
+		if err = parseLogfmtLine(strings.TrimSuffix(line, "\r"), values); err != nil {
+			return nil, fmt.Errorf("error convert from logfmt, line %d, %w", i+1, err)
+		}
+	}
+	return values, nil
+}
+
+// FromLogfmt parses the given logfmt text into dest, panicking if it fails. See FromLogfmtWithErr.
+func FromLogfmt(a, dest any) {
+	if err := FromLogfmtWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// FromLogfmtWithErr parses the given logfmt text with ToLogfmtMapWithErr and fills dest, which must be a pointer
+// to a struct or a map.
+//
+// Fields are matched by the `logfmt` tag, then the `json` tag, then the field name, case insensitively. Values are
+// converted with the rules of ToDestWithErr, so "42" fills an int, "1.5s" a time.Duration and "true" a bool,
+// while values separated by "," fill slices. Keys with dots, such as "user.id", fill the nested struct or map of
+// a field named by the part before the dot, while map destinations, such as a *map[string]any, receive the keys as
+// they are, so "a=1 a.b=2" gives {"a": "1", "a.b": "2"}.
+//
+// Parameters:
+//   - a: The logfmt text, usually a string or a byte slice.
+//   - dest: The pointer to be filled.
+//
+// Returns:
+//   - error: An error is returned in case of failure to parse or to convert a value.
+//
+// Example:
+//
+//	var entry struct {
+//		Level  string        `logfmt:"level"`
+//		Status int           `logfmt:"status"`
+//		Took   time.Duration `logfmt:"took"`
+//		User   struct {
+//			ID int `logfmt:"id"`
+//		} `logfmt:"user"`
+//	}
+//	err := FromLogfmtWithErr(`level=info status=200 took=1.5s user.id=42`, &entry)
+//	fmt.Println(entry.Status, entry.Took, entry.User.ID, err) // 200 1.5s 42 <nil>
+func FromLogfmtWithErr(a, dest any) error {
+	values, err := ToLogfmtMapWithErr(a)
+	if err != nil {
+		return err
+	}
+	return weakDecodeMap(values, dest, "", weakDecoder{tags: logfmtTags, separator: ",", prefixSeparator: "."})
+}
+
+// parseLogfmtLine parses the pairs of a single line into values.
+func parseLogfmtLine(line string, values map[string]string) error {
+	for i := 0; i < len(line); {
+		if line[i] <= ' ' {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(line) && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			return fmt.Errorf("column %d: unexpected %q", logfmtColumn(line, i), line[i])
+		} else if i >= len(line) || line[i] != '=' {
+			values[key] = ""
+			continue
+		}
+
+		i++
+		if i < len(line) && line[i] == '"' {
+			end := logfmtClosingQuote(line, i)
+			if end < 0 {
+				return fmt.Errorf("column %d: unterminated quoted value", logfmtColumn(line, i))
+			}
+			value, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return fmt.Errorf("column %d: invalid quoted value", logfmtColumn(line, i))
+			}
+			values[key] = value
+			i = end + 1
+			continue
+		}
+
+		start = i
+		for i < len(line) && line[i] > ' ' && line[i] != '"' {
+			i++
+		}
+		values[key] = line[start:i]
+	}
+	return nil
+}
+
+// logfmtClosingQuote returns the index of the quote closing the one at start, or -1 if there is none.
+func logfmtClosingQuote(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// logfmtColumn returns the column of the given byte index, counted in characters and starting at 1.
+func logfmtColumn(line string, index int) int {
+	return utf8.RuneCountInString(line[:index]) + 1
+}
+
+func isLogfmtKey(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] <= ' ' || s[i] == '=' || s[i] == '"' || s[i] == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func quoteLogfmt(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || r == utf8.RuneError {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type logfmtTestUser struct {
+	ID    int    `logfmt:"id"`
+	Email string `json:"email,omitempty"`
+}
+
+type logfmtTestEntry struct {
+	Time    time.Time      `logfmt:"ts"`
+	Level   string         `logfmt:"level"`
+	Message string         `logfmt:"msg"`
+	Took    time.Duration  `logfmt:"took"`
+	Status  int            `logfmt:"status"`
+	OK      bool           `logfmt:"ok"`
+	Tags    []string       `logfmt:"tags"`
+	User    logfmtTestUser `logfmt:"user"`
+	Secret  string         `logfmt:"-"`
+}
+
+func TestToLogfmtWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Struct",
+			input: logfmtTestEntry{
+				Time:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+				Level:   "info",
+				Message: "user \"ana\" created\n",
+				Took:    1500 * time.Millisecond,
+				Status:  201,
+				OK:      true,
+				Tags:    []string{"a", "b"},
+				User:    logfmtTestUser{ID: 42},
+				Secret:  "x",
+			},
+			want: `ts=2024-05-01T10:00:00Z level=info msg="user \"ana\" created\n" took=1.5s status=201 ok=true ` +
+				`tags=a,b user.id=42`,
+		},
+		{
+			name:  "Map in sorted order",
+			input: map[string]any{"path": `C:\tmp`, "empty": "", "query": "a=b", "b": map[string]int{"y": 2, "x": 1}},
+			want:  `b.x=1 b.y=2 empty= path=C:\tmp query="a=b"`,
+		},
+		{name: "Pointer", input: &logfmtTestUser{ID: 1, Email: "é@x"}, want: "id=1 email=é@x"},
+		{name: "Control characters", input: map[string]string{"v": "a\tb\x7f"}, want: `v="a\tb\x7f"`},
+		{name: "Invalid key", input: map[string]int{"a b": 1}, wantErr: true},
+		{name: "Not a struct or map", input: []int{1}, wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToLogfmtWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToLogfmtWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToLogfmtWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToLogfmtMapWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "Pairs",
+			input: "\ufeff level=warn  msg=\"disk \\\"/data\\\" almost full\" used=91.5 retry url=/a?b=c empty=\t",
+			want: map[string]string{
+				"level": "warn", "msg": `disk "/data" almost full`, "used": "91.5", "retry": "", "url": "/a?b=c",
+				"empty": "",
+			},
+		},
+		{
+			name:  "Lines",
+			input: []byte("a=1 b=\"\\u00e9\"\r\n\na=2\n"),
+			want:  map[string]string{"a": "2", "b": "é"},
+		},
+		{name: "Empty", input: "", want: map[string]string{}},
+		{
+			name:    "Unterminated quote",
+			input:   "a=1\nmsg=\"abc",
+			wantErr: "error convert from logfmt, line 2, column 5: unterminated quoted value",
+		},
+		{
+			name:    "Invalid escape",
+			input:   `msg="\q"`,
+			wantErr: "error convert from logfmt, line 1, column 5: invalid quoted value",
+		},
+		{name: "Missing key", input: "é =1", wantErr: `error convert from logfmt, line 1, column 3: unexpected '='`},
+		{name: "Quote in value", input: `a=x"y"`, wantErr: `error convert from logfmt, line 1, column 4: unexpected '"'`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToLogfmtMapWithErr(tc.input)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("ToLogfmtMapWithErr() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("ToLogfmtMapWithErr() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToLogfmtMapWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromLogfmtWithErr(t *testing.T) {
+	want := logfmtTestEntry{
+		Time:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Level:   "error",
+		Message: "a \"b\"\tc",
+		Took:    250 * time.Millisecond,
+		Status:  500,
+		OK:      true,
+		Tags:    []string{"x", "y"},
+		User:    logfmtTestUser{ID: 7, Email: "a@b"},
+	}
+
+	var got logfmtTestEntry
+	if err := FromLogfmtWithErr(ToLogfmt(want), &got); err != nil {
+		t.Fatalf("FromLogfmtWithErr() error = %v", err)
+	} else if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromLogfmtWithErr() = %+v, want %+v", got, want)
+	}
+
+	got = logfmtTestEntry{}
+	input := `LEVEL=info status="404" took=2s ok=1 tags= user.id=3 unknown=x secret=y`
+	if err := FromLogfmtWithErr(input, &got); err != nil {
+		t.Fatalf("FromLogfmtWithErr() error = %v", err)
+	}
+	if got.Level != "info" || got.Status != 404 || got.Took != 2*time.Second || !got.OK || got.User.ID != 3 ||
+		len(got.Tags) != 0 || got.Secret != "" {
+		t.Errorf("FromLogfmtWithErr() = %+v", got)
+	}
+
+	var values map[string]string
+	FromLogfmt("a=1 b.c=2", &values)
+	if !reflect.DeepEqual(values, map[string]string{"a": "1", "b.c": "2"}) {
+		t.Errorf("FromLogfmt() = %v", values)
+	}
+
+	var tree map[string]any
+	FromLogfmt("a=1 a.b=2 c.d=3", &tree)
+	if !reflect.DeepEqual(tree, map[string]any{"a": "1", "a.b": "2", "c.d": "3"}) {
+		t.Errorf("FromLogfmt() = %v", tree)
+	}
+
+	var nested struct {
+		User   logfmtTestUser    `logfmt:"user"`
+		Labels map[string]string `logfmt:"labels"`
+	}
+	FromLogfmt("user.id=5 labels.env=prod labels.app.name=api", &nested)
+	if nested.User.ID != 5 || !reflect.DeepEqual(nested.Labels, map[string]string{"env": "prod", "app.name": "api"}) {
+		t.Errorf("FromLogfmt() = %+v", nested)
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		dest  any
+	}{
+		{"Invalid number", "status=abc", &logfmtTestEntry{}},
+		{"Invalid line", `msg="abc`, &logfmtTestEntry{}},
+		{"Conflicting keys", "user=1 user.id=2", &logfmtTestEntry{}},
+		{"Non pointer dest", "a=1", logfmtTestEntry{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := FromLogfmtWithErr(tc.input, tc.dest); err == nil {
+				t.Error("FromLogfmtWithErr() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MonthYear represents a calendar month of a given year, such as the expiry of a payment card.
+type MonthYear struct {
+	Month time.Month
+	Year  int
+}
+
+// String returns the month and year in the "MM/YYYY" form, e.g. "10/2026".
+func (m MonthYear) String() string {
+	return fmt.Sprintf("%02d/%04d", int(m.Month), m.Year)
+}
+
+// Start returns the first instant of the month in the given location. A nil location means UTC.
+func (m MonthYear) Start(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, loc)
+}
+
+// End returns the last instant of the month in the given location. A nil location means UTC.
+func (m MonthYear) End(loc *time.Location) time.Time {
+	return m.Start(loc).AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// IsExpired reports whether the month is already over at the given instant. The end of the month is computed in
+// the location of 'at', so a card expiring in "10/26" is still valid during the whole of October 2026 wherever
+// the check happens.
+func (m MonthYear) IsExpired(at time.Time) bool {
+	return at.After(m.End(at.Location()))
+}
+
+// CouldBeMonthYear checks if the given value can be converted to a MonthYear using ToMonthYearWithErr.
+//
+// Example:
+//
+//	fmt.Println(CouldBeMonthYear("10/26"))  // true
+//	fmt.Println(CouldBeMonthYear("13/26"))  // false
+func CouldBeMonthYear(a any) bool {
+	_, err := ToMonthYearWithErr(a)
+	return err == nil
+}
+
+// ToMonthYear converts the given value to a MonthYear, panicking if the conversion fails.
+// See ToMonthYearWithErr for the accepted inputs.
+func ToMonthYear(a any) MonthYear {
+	m, err := ToMonthYearWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToMonthYearWithErr converts the given value to a MonthYear.
+//
+// Supported inputs:
+//   - MonthYear: returned as is.
+//   - time.Time: its month and year.
+//   - String and byte slices in the forms "10/26", "10/2026", "10-2026", "2026-10", "2026/10", "1026" (MMYY),
+//     "102026" (MMYYYY) and "202610" (YYYYMM). Surrounding spaces are ignored.
+//   - Pointers and interfaces: resolved recursively.
+//
+// Two-digit years are expanded with DefaultTwoDigitYearPivot, see ToMonthYearWithPivotWithErr.
+//
+// Example:
+//
+//	m, err := ToMonthYearWithErr("10/26")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(m.Month, m.Year) // October 2026
+func ToMonthYearWithErr(a any) (MonthYear, error) {
+	return ToMonthYearWithPivotWithErr(a, DefaultTwoDigitYearPivot)
+}
+
+// ToMonthYearWithPivot converts the given value to a MonthYear expanding two-digit years with the given pivot,
+// panicking if the conversion fails. See ToMonthYearWithPivotWithErr.
+func ToMonthYearWithPivot(a any, pivot int) MonthYear {
+	m, err := ToMonthYearWithPivotWithErr(a, pivot)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToMonthYearWithPivotWithErr converts the given value to a MonthYear like ToMonthYearWithErr, expanding two-digit
+// years with the given pivot. A two-digit year lower than the pivot is placed in the 2000s and any other in the
+// 1900s, so a pivot of 100 places every two-digit year in the 2000s.
+//
+// Parameters:
+//   - a: The value to be converted.
+//   - pivot: The pivot, between 0 and 100.
+//
+// Returns:
+//   - MonthYear: The month and year of the value.
+//   - error: An error is returned if the pivot is out of range or in case of failure to convert.
+//
+// Example:
+//
+//	m, _ := ToMonthYearWithPivotWithErr("05/85", 100)
+//	fmt.Println(m.Year) // 2085
+func ToMonthYearWithPivotWithErr(a any, pivot int) (MonthYear, error) {
+	if pivot < 0 || pivot > 100 {
+		return MonthYear{}, fmt.Errorf("invalid two-digit year pivot: %d", pivot)
+	}
+
+	switch v := a.(type) {
+	case MonthYear:
+		return v, nil
+	case time.Time:
+		return MonthYear{Month: v.Month(), Year: v.Year()}, nil
+	}
+
+	reflectValue := reflect.ValueOf(a)
+	switch reflectValue.Kind() {
+	case reflect.String:
+		return parseMonthYear(reflectValue.String(), pivot)
+	case reflect.Array, reflect.Slice:
+		if reflectValue.Type().Elem().Kind() == reflect.Uint8 {
+			s, err := ToStringWithErr(a)
+			if err != nil {
+				return MonthYear{}, err
+			}
+			return parseMonthYear(s, pivot)
+		}
+		return MonthYear{}, fmt.Errorf("error convert to month year, unsupported type %s", reflectValue.Kind().String())
+	case reflect.Interface, reflect.Pointer:
+		if reflectValue.IsNil() {
+			return MonthYear{}, errors.New("error convert to month year, it is null")
+		}
+		return ToMonthYearWithPivotWithErr(reflectValue.Elem().Interface(), pivot)
+	default:
+		return MonthYear{}, fmt.Errorf("error convert to month year, unsupported type %s", reflectValue.Kind().String())
+	}
+}
+
+// ToMonthStartWithErr converts the given value to a MonthYear and returns the first instant of that month in the
+// given location. A nil location means UTC.
+//
+// Example:
+//
+//	t, _ := ToMonthStartWithErr("2026-10", time.UTC)
+//	fmt.Println(t) // 2026-10-01 00:00:00 +0000 UTC
+func ToMonthStartWithErr(a any, loc *time.Location) (time.Time, error) {
+	m, err := ToMonthYearWithErr(a)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return m.Start(loc), nil
+}
+
+// ToMonthEndWithErr converts the given value to a MonthYear and returns the last instant of that month in the
+// given location. A nil location means UTC.
+//
+// Example:
+//
+//	t, _ := ToMonthEndWithErr("10/26", time.UTC)
+//	fmt.Println(t) // 2026-10-31 23:59:59.999999999 +0000 UTC
+func ToMonthEndWithErr(a any, loc *time.Location) (time.Time, error) {
+	m, err := ToMonthYearWithErr(a)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return m.End(loc), nil
+}
+
+// IsExpiredWithErr converts the given value to a MonthYear, typically a payment card expiry, and reports whether
+// it is already over at the given instant.
+//
+// Example:
+//
+//	at := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+//	expired, _ := IsExpiredWithErr("10/26", at)
+//	fmt.Println(expired) // true
+func IsExpiredWithErr(a any, at time.Time) (bool, error) {
+	m, err := ToMonthYearWithErr(a)
+	if err != nil {
+		return false, err
+	}
+	return m.IsExpired(at), nil
+}
+
+func parseMonthYear(s string, pivot int) (MonthYear, error) {
+	s = strings.TrimSpace(s)
+
+	var first, second string
+	if i := strings.IndexAny(s, "/-. "); i >= 0 {
+		first, second = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	} else {
+		switch {
+		case len(s) == 4:
+			first, second = s[:2], s[2:]
+		case len(s) == 6 && isMonthDigits(s[:2]):
+			first, second = s[:2], s[2:]
+		case len(s) == 6:
+			first, second = s[:4], s[4:]
+		default:
+			return MonthYear{}, fmt.Errorf("error convert to month year, unknown format \"%s\"", s)
+		}
+	}
+
+	monthText, yearText := first, second
+	if len(first) == 4 {
+		monthText, yearText = second, first
+	}
+	if !isDigits(monthText) || !isDigits(yearText) || len(monthText) > 2 ||
+		(len(yearText) != 2 && len(yearText) != 4) {
+		return MonthYear{}, fmt.Errorf("error convert to month year, unknown format \"%s\"", s)
+	}
+
+	month, _ := strconv.Atoi(monthText)
+	year, _ := strconv.Atoi(yearText)
+	if month < 1 || month > 12 {
+		return MonthYear{}, fmt.Errorf("invalid month: %d", month)
+	}
+	if len(yearText) == 2 {
+		y4, err := normalize2DigitYearTo4(year, pivot)
+		if err != nil {
+			return MonthYear{}, err
+		}
+		year = y4
+	}
+
+	return MonthYear{Month: time.Month(month), Year: year}, nil
+}
+
+func isMonthDigits(s string) bool {
+	if !isDigits(s) {
+		return false
+	}
+	month, _ := strconv.Atoi(s)
+	return month >= 1 && month <= 12
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
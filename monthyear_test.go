@@ -0,0 +1,123 @@
+package converter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToMonthYearWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    MonthYear
+		wantErr bool
+	}{
+		{"Slash two-digit year", "10/26", MonthYear{time.October, 2026}, false},
+		{"Slash four-digit year", "10/2026", MonthYear{time.October, 2026}, false},
+		{"ISO year month", "2026-10", MonthYear{time.October, 2026}, false},
+		{"Compact MMYY", "1026", MonthYear{time.October, 2026}, false},
+		{"Compact MMYYYY", "102026", MonthYear{time.October, 2026}, false},
+		{"Compact YYYYMM", "202610", MonthYear{time.October, 2026}, false},
+		{"Single digit month", " 1/27 ", MonthYear{time.January, 2027}, false},
+		{"Past century", "05/85", MonthYear{time.May, 1985}, false},
+		{"Bytes", []byte("03/30"), MonthYear{time.March, 2030}, false},
+		{"Time", time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC), MonthYear{time.October, 2026}, false},
+		{"Invalid month", "13/26", MonthYear{}, true},
+		{"Invalid format", "October", MonthYear{}, true},
+		{"Unsupported", 1026, MonthYear{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToMonthYearWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToMonthYearWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToMonthYearWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToMonthYearWithPivotWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		pivot   int
+		want    int
+		wantErr bool
+	}{
+		{"Every year in the 2000s", "05/85", 100, 2085, false},
+		{"Low pivot", "05/26", 20, 1926, false},
+		{"Four-digit year", "05/1926", 100, 1926, false},
+		{"Pointer", ToPointer("05/69"), 0, 1969, false},
+		{"Negative pivot", "05/26", -1, 0, true},
+		{"Pivot above 100", "05/26", 101, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToMonthYearWithPivotWithErr(tc.input, tc.pivot)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToMonthYearWithPivotWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got.Year != tc.want {
+				t.Errorf("ToMonthYearWithPivotWithErr() year = %d, want %d", got.Year, tc.want)
+			}
+		})
+	}
+
+	if got := ToMonthYear("05/85"); got.Year != 1985 {
+		t.Errorf("ToMonthYear() year = %d, want 1985", got.Year)
+	}
+	if got := ToMonthYearWithPivot("05/85", 90); got.Year != 2085 {
+		t.Errorf("ToMonthYearWithPivot() year = %d, want 2085", got.Year)
+	}
+}
+
+func TestMonthYearToTimeFromInts(t *testing.T) {
+	got, err := MonthYearToTimeFromInts(10, 26, nil)
+	if err != nil {
+		t.Fatalf("MonthYearToTimeFromInts() error = %v", err)
+	}
+	if want := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("MonthYearToTimeFromInts() = %v, want %v", got, want)
+	}
+}
+
+func TestToMonthEndWithErr(t *testing.T) {
+	got, err := ToMonthEndWithErr("02/28", time.UTC)
+	if err != nil {
+		t.Fatalf("ToMonthEndWithErr() error = %v", err)
+	}
+	want := time.Date(2028, time.March, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+	if !got.Equal(want) {
+		t.Errorf("ToMonthEndWithErr() = %v, want %v", got, want)
+	}
+}
+
+func TestIsExpiredWithErr(t *testing.T) {
+	testCases := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"First day", time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), false},
+		{"Last instant", time.Date(2026, time.October, 31, 23, 59, 59, 0, time.UTC), false},
+		{"Next month", time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := IsExpiredWithErr("10/26", tc.at)
+			if err != nil {
+				t.Fatalf("IsExpiredWithErr() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("IsExpiredWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
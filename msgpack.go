@@ -0,0 +1,471 @@
+package converter
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// msgpackTags are the struct tags that name fields in MessagePack maps, in order of precedence.
+var msgpackTags = []string{"msgpack", "json"}
+
+// msgpackMaxDepth limits the nesting of decoded MessagePack values, so that malicious input cannot exhaust the stack.
+const msgpackMaxDepth = 1000
+
+// msgpackTimestampExt is the extension type of MessagePack timestamps.
+const msgpackTimestampExt = -1
+
+// MsgPack is a MessagePack document, as returned by ToMsgPack. Wrapping bytes in it tells ToDestWithErr to decode
+// them with FromMsgPackWithErr, since MessagePack cannot be told apart from text, while ToBytesWithErr returns them
+// unchanged.
+//
+// Example:
+//
+//	var item map[string]int
+//	err := ToDestWithErr(MsgPack(ToMsgPack(map[string]any{"id": 7})), &item)
+//	fmt.Println(item, err) // map[id:7] <nil>
+type MsgPack []byte
+
+// ToMsgPack converts the given value to MessagePack, panicking if the conversion fails. See ToMsgPackWithErr.
+func ToMsgPack(a any) []byte {
+	bs, err := ToMsgPackWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return bs
+}
+
+// ToMsgPackWithErr converts the given value to MessagePack, the binary counterpart of ToStringWithErr for
+// structured values.
+//
+// Values are encoded as follows:
+//   - nil, nil pointers, nil maps and nil slices: nil.
+//   - Booleans, strings and floats: their MessagePack type, keeping the width of floats.
+//   - Integers: the smallest integer format that holds the value.
+//   - Byte slices and arrays: bin.
+//   - time.Time: the timestamp extension, type -1, in its smallest format.
+//   - Types implementing encoding.TextMarshaler, such as UUID and Date, and types registered with RegisterFlags:
+//     strings, as rendered by MarshalText or ToStringWithErr.
+//   - Slices and arrays: arrays.
+//   - Maps: maps whose keys are strings, as rendered by ToStringWithErr, sorted so that the output is stable.
+//   - Structs: maps whose keys are the `msgpack` tag, the `json` tag or the field name. Fields tagged with "-" are
+//     skipped, and so are zero fields with the omitempty option. Embedded structs have their fields promoted.
+//
+// Parameters:
+//   - a: The value of any type to be converted to MessagePack.
+//
+// Returns:
+//   - []byte: The MessagePack representation of the provided value.
+//   - error: An error is returned in case of failure to convert, e.g. for channels or functions.
+//
+// Example:
+//
+//	bs, _ := ToMsgPackWithErr(map[string]any{"id": 1, "ok": true})
+//	fmt.Printf("%x", bs) // 82a2696401a26f6bc3
+func ToMsgPackWithErr(a any) ([]byte, error) {
+	return appendMsgPack(nil, reflect.ValueOf(a))
+}
+
+// FromMsgPack decodes the given MessagePack into dest, panicking if decoding fails. See FromMsgPackWithErr.
+func FromMsgPack(a, dest any) {
+	if err := FromMsgPackWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// FromMsgPackWithErr converts the given value to a byte slice using ToBytesWithErr and decodes it as MessagePack
+// into dest, with the weak typing of ToDestWithErr, so that a string "10" fills an int and an integer fills a
+// string.
+//
+// Struct fields are named as in ToMsgPackWithErr and matched case insensitively when there is no exact match.
+// Timestamps fill time.Time, binary and strings fill byte slices, and nil leaves the destination unchanged. An
+// interface destination, such as *any, receives a generic tree made of nil, bool, int64, uint64 for integers above
+// math.MaxInt64, float64, string, []byte, time.Time, []any and map[string]any.
+//
+// Example:
+//
+//	type Item struct {
+//		ID    int     `msgpack:"id"`
+//		Price float64 `msgpack:"price"`
+//	}
+//	var item Item
+//	err := FromMsgPackWithErr(ToMsgPack(map[string]any{"id": "7", "price": 9.9}), &item)
+//	fmt.Println(item, err) // {7 9.9} <nil>
+func FromMsgPackWithErr(a, dest any) error {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return err
+	}
+
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer {
+		return errors.New("dest is not a pointer")
+	} else if reflectDest.IsNil() {
+		return errors.New("dest is nil")
+	}
+
+	decoder := &msgpackDecoder{data: bs}
+	value, err := decoder.decode(0)
+	if err != nil {
+		return err
+	} else if decoder.offset != len(bs) {
+		return fmt.Errorf("error convert from MessagePack, %d unexpected bytes after value", len(bs)-decoder.offset)
+	}
+	return weakDecoder{tags: msgpackTags}.decode(value, reflectDest.Elem())
+}
+
+func appendMsgPack(bs []byte, value reflect.Value) ([]byte, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return append(bs, 0xc0), nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return append(bs, 0xc0), nil
+	}
+
+	if t, ok := timeIfPresent(value); ok {
+		return appendMsgPackTimestamp(bs, t), nil
+	} else if _, ok := lookupFlagSet(value.Type()); ok || value.Type().Implements(textMarshalerType) {
+		var s string
+		var err error
+		if ok {
+			s, err = ToStringWithErr(value.Interface())
+		} else {
+			var text []byte
+			text, err = value.Interface().(encoding.TextMarshaler).MarshalText()
+			s = string(text)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return appendMsgPackString(bs, 0xa0, 0xd9, s), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			return append(bs, 0xc3), nil
+		}
+		return append(bs, 0xc2), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendMsgPackInt(bs, value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendMsgPackUint(bs, value.Uint()), nil
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(append(bs, 0xca), math.Float32bits(float32(value.Float()))), nil
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(bs, 0xcb), math.Float64bits(value.Float())), nil
+	case reflect.String:
+		return appendMsgPackString(bs, 0xa0, 0xd9, value.String()), nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return append(bs, 0xc0), nil
+		} else if value.Type().Elem().Kind() == reflect.Uint8 {
+			s, err := ToStringWithErr(value.Interface())
+			if err != nil {
+				return nil, err
+			}
+			return appendMsgPackString(bs, 0, 0xc4, s), nil
+		}
+		bs = appendMsgPackHeader(bs, 0x90, 0xdc, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			var err error
+			if bs, err = appendMsgPack(bs, value.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return bs, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return append(bs, 0xc0), nil
+		}
+		keys, byKey, err := sortedMapKeys(value)
+		if err != nil {
+			return nil, err
+		}
+		bs = appendMsgPackHeader(bs, 0x80, 0xde, len(keys))
+		for _, key := range keys {
+			bs = appendMsgPackString(bs, 0xa0, 0xd9, key)
+			if bs, err = appendMsgPack(bs, byKey[key]); err != nil {
+				return nil, err
+			}
+		}
+		return bs, nil
+	case reflect.Struct:
+		var fields []weakField
+		for _, field := range weakFields(value.Type(), msgpackTags) {
+			if !field.omitEmpty || !value.FieldByIndex(field.index).IsZero() {
+				fields = append(fields, field)
+			}
+		}
+		bs = appendMsgPackHeader(bs, 0x80, 0xde, len(fields))
+		for _, field := range fields {
+			var err error
+			bs = appendMsgPackString(bs, 0xa0, 0xd9, field.name)
+			if bs, err = appendMsgPack(bs, value.FieldByIndex(field.index)); err != nil {
+				return nil, err
+			}
+		}
+		return bs, nil
+	default:
+		return nil, fmt.Errorf("error convert to MessagePack, unsupported type %s", value.Kind().String())
+	}
+}
+
+func appendMsgPackInt(bs []byte, i int64) []byte {
+	switch {
+	case i >= 0:
+		return appendMsgPackUint(bs, uint64(i))
+	case i >= -32:
+		return append(bs, byte(i))
+	case i >= math.MinInt8:
+		return append(bs, 0xd0, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(bs, 0xd1), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(bs, 0xd2), uint32(i))
+	default:
+		return binary.BigEndian.AppendUint64(append(bs, 0xd3), uint64(i))
+	}
+}
+
+func appendMsgPackUint(bs []byte, u uint64) []byte {
+	switch {
+	case u <= 0x7f:
+		return append(bs, byte(u))
+	case u <= math.MaxUint8:
+		return append(bs, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(bs, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(bs, 0xce), uint32(u))
+	default:
+		return binary.BigEndian.AppendUint64(append(bs, 0xcf), u)
+	}
+}
+
+// appendMsgPackString appends a str, when fix is not zero, or a bin, whose 8, 16 and 32 bits formats start at code.
+func appendMsgPackString(bs []byte, fix, code byte, s string) []byte {
+	switch n := len(s); {
+	case fix != 0 && n < 32:
+		bs = append(bs, fix|byte(n))
+	case n <= math.MaxUint8:
+		bs = append(bs, code, byte(n))
+	case n <= math.MaxUint16:
+		bs = binary.BigEndian.AppendUint16(append(bs, code+1), uint16(n))
+	default:
+		bs = binary.BigEndian.AppendUint32(append(bs, code+2), uint32(n))
+	}
+	return append(bs, s...)
+}
+
+// appendMsgPackHeader appends the header of an array or map, whose 16 and 32 bits formats start at code.
+func appendMsgPackHeader(bs []byte, fix, code byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(bs, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(bs, code), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(bs, code+1), uint32(n))
+	}
+}
+
+func appendMsgPackTimestamp(bs []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		return binary.BigEndian.AppendUint32(append(bs, 0xd6, 0xff), uint32(sec))
+	case sec >= 0 && sec < 1<<34:
+		return binary.BigEndian.AppendUint64(append(bs, 0xd7, 0xff), uint64(nsec)<<34|uint64(sec))
+	default:
+		bs = binary.BigEndian.AppendUint32(append(bs, 0xc7, 12, 0xff), uint32(nsec))
+		return binary.BigEndian.AppendUint64(bs, uint64(sec))
+	}
+}
+
+type msgpackDecoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *msgpackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || n > len(d.data)-d.offset {
+		return nil, fmt.Errorf("error convert from MessagePack, unexpected end of data at offset %d", d.offset)
+	}
+	bs := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return bs, nil
+}
+
+func (d *msgpackDecoder) readUint(size int) (uint64, error) {
+	bs, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, b := range bs {
+		u = u<<8 | uint64(b)
+	}
+	return u, nil
+}
+
+func (d *msgpackDecoder) decode(depth int) (any, error) {
+	if depth > msgpackMaxDepth {
+		return nil, errors.New("error convert from MessagePack, maximum nesting depth exceeded")
+	}
+
+	offset := d.offset
+	bs, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch code := bs[0]; {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	case code&0xe0 == 0xa0:
+		return d.decodeString(int(code & 0x1f))
+	case code&0xf0 == 0x90:
+		return d.decodeArray(int(code&0x0f), depth)
+	case code&0xf0 == 0x80:
+		return d.decodeMap(int(code&0x0f), depth)
+	case code == 0xc0:
+		return nil, nil
+	case code == 0xc2 || code == 0xc3:
+		return code == 0xc3, nil
+	case code >= 0xc4 && code <= 0xc6:
+		n, err := d.readUint(1 << (code - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		bs, err := d.read(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), bs...), nil
+	case code >= 0xc7 && code <= 0xc9:
+		n, err := d.readUint(1 << (code - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(int(n), offset)
+	case code == 0xca:
+		u, err := d.readUint(4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case code == 0xcb:
+		u, err := d.readUint(8)
+		return math.Float64frombits(u), err
+	case code >= 0xcc && code <= 0xcf:
+		u, err := d.readUint(1 << (code - 0xcc))
+		if err != nil || u > math.MaxInt64 {
+			return u, err
+		}
+		return int64(u), nil
+	case code >= 0xd0 && code <= 0xd3:
+		size := 1 << (code - 0xd0)
+		u, err := d.readUint(size)
+		return int64(u<<(64-8*size)) >> (64 - 8*size), err
+	case code >= 0xd4 && code <= 0xd8:
+		return d.decodeExt(1<<(code-0xd4), offset)
+	case code >= 0xd9 && code <= 0xdb:
+		n, err := d.readUint(1 << (code - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+	case code == 0xdc || code == 0xdd:
+		n, err := d.readUint(2 << (code - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n), depth)
+	case code == 0xde || code == 0xdf:
+		n, err := d.readUint(2 << (code - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n), depth)
+	default:
+		return nil, fmt.Errorf("error convert from MessagePack, invalid code 0x%02x at offset %d", code, offset)
+	}
+}
+
+func (d *msgpackDecoder) decodeString(n int) (any, error) {
+	bs, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(bs), nil
+}
+
+func (d *msgpackDecoder) decodeArray(n int, depth int) (any, error) {
+	if n > len(d.data)-d.offset {
+		return nil, fmt.Errorf("error convert from MessagePack, unexpected end of data at offset %d", d.offset)
+	}
+	items := make([]any, n)
+	for i := range items {
+		item, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func (d *msgpackDecoder) decodeMap(n int, depth int) (any, error) {
+	if n > (len(d.data)-d.offset)/2 {
+		return nil, fmt.Errorf("error convert from MessagePack, unexpected end of data at offset %d", d.offset)
+	}
+	fields := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		key, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		name, err := ToStringWithErr(key)
+		if err != nil {
+			return nil, fmt.Errorf("error convert from MessagePack, unsupported map key: %w", err)
+		}
+		if fields[name], err = d.decode(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func (d *msgpackDecoder) decodeExt(n int, offset int) (any, error) {
+	extType, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := d.read(n)
+	if err != nil {
+		return nil, err
+	} else if int8(extType[0]) != msgpackTimestampExt {
+		return nil, fmt.Errorf("error convert from MessagePack, unsupported extension type %d at offset %d",
+			int8(extType[0]), offset)
+	}
+
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(bs)), 0).UTC(), nil
+	case 8:
+		u := binary.BigEndian.Uint64(bs)
+		return time.Unix(int64(u&(1<<34-1)), int64(u>>34)).UTC(), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(bs)
+		return time.Unix(int64(binary.BigEndian.Uint64(bs[4:])), int64(nsec)).UTC(), nil
+	default:
+		return nil, fmt.Errorf("error convert from MessagePack, invalid timestamp length %d at offset %d", n, offset)
+	}
+}
@@ -0,0 +1,193 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type msgpackTestItem struct {
+	SKU   string  `msgpack:"sku"`
+	Price float32 `json:"price"`
+	Note  string  `msgpack:"note,omitempty"`
+	Skip  string  `msgpack:"-"`
+}
+
+type msgpackTestOrder struct {
+	ID       int64             `msgpack:"id"`
+	Paid     bool              `msgpack:"paid"`
+	Items    []msgpackTestItem `msgpack:"items"`
+	Tags     map[string]int    `msgpack:"tags"`
+	Payload  []byte            `msgpack:"payload"`
+	Created  time.Time         `msgpack:"created"`
+	Customer UUID              `msgpack:"customer"`
+	Parent   *msgpackTestOrder `msgpack:"parent"`
+}
+
+func TestToMsgPackWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{"Nil", nil, "c0", false},
+		{"Nil pointer", (*int)(nil), "c0", false},
+		{"Bool", true, "c3", false},
+		{"Positive fixint", 127, "7f", false},
+		{"Negative fixint", -32, "e0", false},
+		{"Uint8", uint16(200), "ccc8", false},
+		{"Uint16", 65535, "cdffff", false},
+		{"Uint32", 1 << 20, "ce00100000", false},
+		{"Uint64", uint64(math.MaxUint64), "cfffffffffffffffff", false},
+		{"Int8", -100, "d09c", false},
+		{"Int16", -1000, "d1fc18", false},
+		{"Int32", -100000, "d2fffe7960", false},
+		{"Int64", int64(math.MinInt64), "d38000000000000000", false},
+		{"Float32", float32(1.5), "ca3fc00000", false},
+		{"Float64", 1.5, "cb3ff8000000000000", false},
+		{"Fixstr", "hi", "a26869", false},
+		{"Str8", string(bytes.Repeat([]byte("a"), 32)), "d920" + hex.EncodeToString(bytes.Repeat([]byte("a"), 32)), false},
+		{"Bin", []byte{1, 2}, "c4020102", false},
+		{"Array", []any{1, "a", nil}, "9301a161c0", false},
+		{"Map sorted", map[string]any{"ok": true, "id": 1}, "82a2696401a26f6bc3", false},
+		{"Map int keys", map[int]bool{2: false, 1: true}, "82a131c3a132c2", false},
+		{"Struct", msgpackTestItem{SKU: "A", Price: 2, Skip: "x"}, "82a3736b75a141a57072696365ca40000000", false},
+		{"Timestamp 32", time.Unix(1, 0), "d6ff00000001", false},
+		{"Timestamp 64", time.Unix(1, 1), "d7ff0000000400000001", false},
+		{"Timestamp 96", time.Unix(-1, 0), "c70cff00000000ffffffffffffffff", false},
+		{"Text marshaler", ToUUID("0190a7b2-1c3d-7e4f-8a5b-6c7d8e9f0a1b"),
+			"d924" + hex.EncodeToString([]byte("0190a7b2-1c3d-7e4f-8a5b-6c7d8e9f0a1b")), false},
+		{"Unsupported", make(chan int), "", true},
+		{"Unsupported nested", map[string]any{"f": func() {}}, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToMsgPackWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToMsgPackWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if hex.EncodeToString(got) != tc.want {
+				t.Errorf("ToMsgPackWithErr() = %x, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromMsgPackWithErr(t *testing.T) {
+	order := msgpackTestOrder{
+		ID:       42,
+		Paid:     true,
+		Items:    []msgpackTestItem{{SKU: "A1", Price: 9.5, Note: "gift"}, {SKU: "B2", Price: 1}},
+		Tags:     map[string]int{"a": 1},
+		Payload:  []byte{0, 255},
+		Created:  time.Date(2024, 5, 1, 10, 0, 0, 123, time.UTC),
+		Customer: ToUUID("0190a7b2-1c3d-7e4f-8a5b-6c7d8e9f0a1b"),
+		Parent:   &msgpackTestOrder{ID: 1},
+	}
+
+	var got msgpackTestOrder
+	if err := FromMsgPackWithErr(ToMsgPack(order), &got); err != nil {
+		t.Fatalf("FromMsgPackWithErr() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, order) {
+		t.Errorf("FromMsgPackWithErr() = %+v, want %+v", got, order)
+	}
+
+	var weak struct {
+		ID    int     `msgpack:"id"`
+		Price float64 `msgpack:"price"`
+		Code  string  `msgpack:"code"`
+		Count map[int]uint8
+	}
+	input := map[string]any{"ID": "7", "price": float32(9.5), "code": 10, "count": map[string]any{"1": 2}}
+	if err := FromMsgPackWithErr(ToMsgPack(input), &weak); err != nil {
+		t.Fatalf("FromMsgPackWithErr() error = %v", err)
+	}
+	if weak.ID != 7 || weak.Price != 9.5 || weak.Code != "10" || weak.Count[1] != 2 {
+		t.Errorf("FromMsgPackWithErr() = %+v", weak)
+	}
+
+	var tree any
+	if err := FromMsgPackWithErr(mustDecodeHex(t, "83a161cfffffffffffffffffa162d0ffa163c4016a"), &tree); err != nil {
+		t.Fatalf("FromMsgPackWithErr() error = %v", err)
+	}
+	want := map[string]any{"a": uint64(math.MaxUint64), "b": int64(-1), "c": []byte("j")}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("FromMsgPackWithErr() = %#v, want %#v", tree, want)
+	}
+}
+
+func TestFromMsgPackWithErrInvalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"Empty", ""},
+		{"Truncated string", "a36162"},
+		{"Truncated array", "9301"},
+		{"Huge map", "dfffffffff"},
+		{"Invalid code", "c1"},
+		{"Unknown extension", "d40101"},
+		{"Invalid timestamp", "d5ff0001"},
+		{"Trailing bytes", "c0c0"},
+		{"Truncated map key", "81c402"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest any
+			if err := FromMsgPackWithErr(mustDecodeHex(t, tc.input), &dest); err == nil {
+				t.Errorf("FromMsgPackWithErr() error = nil, want an error")
+			}
+		})
+	}
+
+	var n int
+	if err := FromMsgPackWithErr(ToMsgPack("abc"), &n); err == nil {
+		t.Error("FromMsgPackWithErr() error = nil, want an error for an invalid number")
+	}
+	if err := FromMsgPackWithErr(ToMsgPack(1), n); err == nil {
+		t.Error("FromMsgPackWithErr() error = nil, want an error for a non pointer dest")
+	}
+	if err := FromMsgPackWithErr(bytes.Repeat([]byte{0x91}, msgpackMaxDepth+2), new(any)); err == nil {
+		t.Error("FromMsgPackWithErr() error = nil, want an error for deep nesting")
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	bs, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bs
+}
+
+func TestToDestWithErrMsgPack(t *testing.T) {
+	order := msgpackTestOrder{ID: 42, Items: []msgpackTestItem{{SKU: "A1"}}, Created: time.Unix(0, 0).UTC()}
+	data := MsgPack(ToMsgPack(order))
+
+	var got msgpackTestOrder
+	if err := ToDestWithErr(data, &got); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	} else if !reflect.DeepEqual(got, order) {
+		t.Errorf("ToDestWithErr() = %+v, want %+v", got, order)
+	}
+
+	var created time.Time
+	if err := ToDestWithErr(MsgPack(ToMsgPack(order.Created)), &created); err != nil || !created.Equal(order.Created) {
+		t.Errorf("ToDestWithErr() = %v, %v, want %v", created, err, order.Created)
+	}
+	var n int
+	if err := ToDestWithErr(&data, &n); err == nil {
+		t.Error("ToDestWithErr() error = nil, want an error for a map into an int")
+	}
+	if bs := ToBytes(data); !bytes.Equal(bs, data) {
+		t.Errorf("ToBytes() = %x, want %x", bs, []byte(data))
+	}
+}
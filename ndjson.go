@@ -0,0 +1,230 @@
+package converter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// NDJSONErrorPolicy tells an NDJSONReader what to do with lines that cannot be decoded.
+type NDJSONErrorPolicy int
+
+const (
+	// NDJSONStopOnError stops the reading at the first line that cannot be decoded, returning its error from then on.
+	NDJSONStopOnError NDJSONErrorPolicy = iota
+	// NDJSONSkipInvalid skips the lines that cannot be decoded, which are reported by NDJSONReader.Errors.
+	NDJSONSkipInvalid
+)
+
+// NDJSONLineError describes a line of an NDJSON stream that could not be decoded.
+type NDJSONLineError struct {
+	// Line is the line in the input, starting at 1.
+	Line int
+	// Text is the content of the line, without the line break.
+	Text string
+	// Err is the decoding error.
+	Err error
+}
+
+// Error returns the line, text and cause of the error.
+func (e *NDJSONLineError) Error() string {
+	return fmt.Sprintf("error convert from NDJSON, line %d, text %q: %v", e.Line, e.Text, e.Err)
+}
+
+// Unwrap returns the decoding error.
+func (e *NDJSONLineError) Unwrap() error {
+	return e.Err
+}
+
+// NDJSONReader reads an NDJSON (JSON Lines) stream one value at a time, so that inputs of any size can be decoded
+// with constant memory. Lines of any length are supported.
+type NDJSONReader struct {
+	reader  *bufio.Reader
+	policy  NDJSONErrorPolicy
+	lenient bool
+	line    int
+	errs    []*NDJSONLineError
+	err     error
+}
+
+// NewNDJSONReader returns an NDJSONReader reading from r, handling the lines that cannot be decoded according to
+// policy.
+//
+// Example:
+//
+//	type Event struct {
+//		ID   int    `json:"id"`
+//		Kind string `json:"kind"`
+//	}
+//	reader := NewNDJSONReader(strings.NewReader("{\"id\":1,\"kind\":\"a\"}\n{\"id\":\"x\"}\n{\"id\":\"3\"}\n"),
+//		NDJSONSkipInvalid)
+//	for {
+//		var event Event
+//		if err := reader.Next(&event); err == io.EOF {
+//			break
+//		} else if err != nil {
+//			return err
+//		}
+//		fmt.Println(event.ID) // 1, then 3
+//	}
+//	fmt.Println(reader.Errors()[0].Line) // 2
+func NewNDJSONReader(r io.Reader, policy NDJSONErrorPolicy) *NDJSONReader {
+	return &NDJSONReader{reader: bufio.NewReader(r), policy: policy}
+}
+
+// NewLenientNDJSONReader is like NewNDJSONReader, but lines that are not valid JSON are read as JSON5, with
+// comments, trailing commas and single quotes, see ToStrictJSONWithErr.
+func NewLenientNDJSONReader(r io.Reader, policy NDJSONErrorPolicy) *NDJSONReader {
+	return &NDJSONReader{reader: bufio.NewReader(r), policy: policy, lenient: true}
+}
+
+// Next decodes the next value of the stream into dest, which must be a non-nil pointer, returning io.EOF once the
+// stream is over. Blank lines are ignored, as are "\r\n" line breaks and a UTF-8 byte order mark at the start.
+//
+// Each line must hold a JSON value, or a JSON5 one for readers made by NewLenientNDJSONReader. Objects and arrays are
+// given to ToDestWithErr as JSON, and the other values once decoded, so numbers given as strings, times, UUIDs and the
+// other types handled by ToDestWithErr are converted. Empty interface destinations receive the decoded value, with
+// numbers as json.Number. A null leaves dest with its zero value.
+//
+// A line that cannot be decoded is reported as a *NDJSONLineError. With NDJSONStopOnError it is returned, and
+// every later call returns it again. With NDJSONSkipInvalid the line is skipped and recorded in Errors, and Next
+// goes on to the next line. Errors reading the stream are always returned and stop the reading.
+func (r *NDJSONReader) Next(dest any) error {
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer || reflectDest.IsNil() {
+		return errors.New("error convert from NDJSON, dest must be a non-nil pointer")
+	}
+
+	for r.err == nil {
+		text, err := r.reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			r.err = fmt.Errorf("error convert from NDJSON: %w", err)
+			break
+		} else if err == io.EOF && len(text) == 0 {
+			r.err = io.EOF
+			break
+		}
+
+		r.line++
+		text = bytes.TrimSuffix(bytes.TrimSuffix(text, []byte("\n")), []byte("\r"))
+		if r.line == 1 {
+			text = StripBOM(text)
+		}
+		if len(bytes.TrimSpace(text)) == 0 {
+			continue
+		}
+
+		if err = decodeNDJSONLine(text, reflectDest, r.lenient); err == nil {
+			return nil
+		}
+		lineErr := &NDJSONLineError{Line: r.line, Text: string(text), Err: err}
+		if r.policy != NDJSONSkipInvalid {
+			r.err = lineErr
+			break
+		}
+		r.errs = append(r.errs, lineErr)
+	}
+	return r.err
+}
+
+// Line returns the number of the last line read by Next, starting at 1.
+func (r *NDJSONReader) Line() int {
+	return r.line
+}
+
+// Errors returns the lines skipped so far with NDJSONSkipInvalid.
+func (r *NDJSONReader) Errors() []*NDJSONLineError {
+	return r.errs
+}
+
+func decodeNDJSONLine(text []byte, reflectDest reflect.Value, lenient bool) error {
+	if !json.Valid(text) {
+		if !lenient {
+			var value any
+			return json.Unmarshal(text, &value)
+		}
+		strict, err := toStrictJSON(text)
+		if err != nil {
+			return err
+		}
+		text = strict
+	}
+
+	isInterface := reflectDest.Elem().Kind() == reflect.Interface && reflectDest.Elem().NumMethod() == 0
+	var value any = text
+	if first := bytes.TrimSpace(text)[0]; isInterface || first != '{' && first != '[' {
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+	}
+
+	if value == nil {
+		reflectDest.Elem().SetZero()
+		return nil
+	} else if isInterface {
+		reflectDest.Elem().Set(reflect.ValueOf(value))
+		return nil
+	}
+	return ToDestWithErr(value, reflectDest.Interface())
+}
+
+// NDJSONWriter writes values to an NDJSON (JSON Lines) stream, one per line. Each value is written straight to the
+// underlying writer, so wrapping it in a bufio.Writer is recommended for large streams.
+type NDJSONWriter struct {
+	writer io.Writer
+}
+
+// NewNDJSONWriter returns an NDJSONWriter writing to w.
+//
+// Example:
+//
+//	writer := NewNDJSONWriter(os.Stdout)
+//	_ = writer.Write(map[string]any{"id": 1})
+//	_ = writer.Write("done")
+//	// {"id":1}
+//	// "done"
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{writer: w}
+}
+
+// Write writes a followed by a line break. Structs, maps and slices are written as compact JSON, strings and byte
+// slices holding a JSON object or array are compacted, and every other string is written as a JSON string, keeping
+// its text unchanged. Other values are written with ToCompactStringWithErr, as JSON strings when the result is not
+// JSON, such as for times, so that every line is a JSON value, and nil is written as null.
+func (w *NDJSONWriter) Write(a any) error {
+	if !isNonNil(indirectValue(a)) {
+		_, err := io.WriteString(w.writer, "null\n")
+		return err
+	}
+
+	var line []byte
+	if isTextSource(a) {
+		bs, err := ToBytesWithErr(a)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if isJSONDocumentStart(bs) && json.Valid(bs) && json.Compact(&buf, bs) == nil {
+			line = buf.Bytes()
+		} else {
+			line = appendJSONString(nil, string(bs))
+		}
+	} else {
+		s, err := ToCompactStringWithErr(a)
+		if err != nil {
+			return err
+		} else if json.Valid([]byte(s)) {
+			line = []byte(s)
+		} else {
+			line = appendJSONString(nil, s)
+		}
+	}
+	_, err := w.writer.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,217 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ndjsonTestEvent struct {
+	ID   int       `json:"id"`
+	Kind string    `json:"kind"`
+	At   time.Time `json:"at"`
+}
+
+func TestNDJSONReader(t *testing.T) {
+	input := "\ufeff{\"id\":1,\"kind\":\"a\",\"at\":\"2024-05-01T10:00:00Z\"}\r\n" +
+		"\n" +
+		"{\"id\":\"x\"}\n" +
+		"   \n" +
+		"{\"id\":3\n" +
+		"{\"id\":4,\"kind\":\"b\"}"
+
+	reader := NewNDJSONReader(strings.NewReader(input), NDJSONSkipInvalid)
+	var got []ndjsonTestEvent
+	var lines []int
+	for {
+		var event ndjsonTestEvent
+		if err := reader.Next(&event); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, event)
+		lines = append(lines, reader.Line())
+	}
+
+	want := []ndjsonTestEvent{{ID: 1, Kind: "a", At: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}, {ID: 4, Kind: "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Next() = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(lines, []int{1, 6}) {
+		t.Errorf("Line() = %v, want [1 6]", lines)
+	}
+
+	errs := reader.Errors()
+	if len(errs) != 2 || errs[0].Line != 3 || errs[0].Text != `{"id":"x"}` || errs[1].Line != 5 ||
+		errs[1].Text != `{"id":3` {
+		t.Fatalf("Errors() = %v", errs)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(errs[1], &syntaxErr) {
+		t.Errorf("Errors()[1] = %v, want a *json.SyntaxError", errs[1].Err)
+	}
+	if reader.Next(new(ndjsonTestEvent)) != io.EOF {
+		t.Error("Next() want io.EOF after the end")
+	}
+}
+
+func TestNDJSONReaderStopOnError(t *testing.T) {
+	reader := NewNDJSONReader(strings.NewReader("1\nnot json\n3\n"), NDJSONStopOnError)
+
+	var n int
+	if err := reader.Next(&n); err != nil || n != 1 {
+		t.Fatalf("Next() = %v, %v, want 1", n, err)
+	}
+	err := reader.Next(&n)
+	var lineErr *NDJSONLineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 || lineErr.Text != "not json" {
+		t.Fatalf("Next() error = %v, want a *NDJSONLineError for line 2", err)
+	}
+	if !strings.HasPrefix(err.Error(), `error convert from NDJSON, line 2, text "not json": `) {
+		t.Errorf("Error() = %v", err)
+	}
+	if again := reader.Next(&n); again != err {
+		t.Errorf("Next() error = %v, want the same error again", again)
+	}
+	if len(reader.Errors()) != 0 {
+		t.Errorf("Errors() = %v, want none", reader.Errors())
+	}
+
+	if err = NewNDJSONReader(strings.NewReader("1"), NDJSONStopOnError).Next(n); err == nil {
+		t.Error("Next() error = nil, want an error for a non pointer dest")
+	}
+	if err = NewNDJSONReader(ndjsonErrReader{}, NDJSONSkipInvalid).Next(&n); err == nil || err == io.EOF {
+		t.Errorf("Next() error = %v, want the read error", err)
+	}
+}
+
+func TestNDJSONReaderTypes(t *testing.T) {
+	input := "42\n\"17\"\n\"2024-05-01\"\n[1,2]\n{\"a\":1.5}\nnull\n{a: 'x',} // comment\n"
+	reader := NewNDJSONReader(strings.NewReader(input), NDJSONStopOnError)
+
+	var n int
+	var s int64
+	var date Date
+	var items []int
+	var tree any
+	var values map[string]any
+	var lenient map[string]string
+	dests := []any{&n, &s, &date, &items, &tree, &items}
+	for _, dest := range dests {
+		if err := reader.Next(dest); err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+	}
+	if n != 42 || s != 17 || date != ToCivilDate("2024-05-01") || items != nil {
+		t.Errorf("Next() = %v, %v, %v, %v", n, s, date, items)
+	}
+	if !reflect.DeepEqual(tree, map[string]any{"a": json.Number("1.5")}) {
+		t.Errorf("Next() = %#v", tree)
+	}
+
+	if err := reader.Next(&values); err == nil {
+		t.Error("Next() error = nil, want an error for JSON5 in a strict reader")
+	}
+
+	reader = NewLenientNDJSONReader(strings.NewReader(input), NDJSONSkipInvalid)
+	for i := 0; i < 6; i++ {
+		if err := reader.Next(new(any)); err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+	}
+	if err := reader.Next(&lenient); err != nil || lenient["a"] != "x" {
+		t.Errorf("Next() = %v, %v", lenient, err)
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+
+	values := []any{
+		ndjsonTestEvent{ID: 1, Kind: "a", At: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		map[string]any{"b": 2, "a": []int{1}},
+		"{\n  \"pretty\": true\n}",
+		"multi\nline   text",
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		12.5,
+		nil,
+		(*ndjsonTestEvent)(nil),
+	}
+	for _, value := range values {
+		if err := writer.Write(value); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	want := `{"id":1,"kind":"a","at":"2024-05-01T10:00:00Z"}
+{"a":[1],"b":2}
+{"pretty":true}
+"multi\nline   text"
+"2024-05-01T00:00:00Z"
+12.5
+null
+null
+`
+	if buf.String() != want {
+		t.Errorf("Write() = %v, want %v", buf.String(), want)
+	}
+
+	reader := NewNDJSONReader(&buf, NDJSONStopOnError)
+	var event ndjsonTestEvent
+	if err := reader.Next(&event); err != nil || event != values[0] {
+		t.Errorf("Next() = %+v, %v, want %+v", event, err, values[0])
+	}
+
+	if err := writer.Write(make(chan int)); err == nil {
+		t.Error("Write() error = nil, want an error for an unsupported value")
+	}
+}
+
+func TestNDJSONWriterStrings(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf)
+
+	values := []string{"hello   world\tx", "  padded ", "123", "true", "null", `"quoted"`, "", " [1, 2] "}
+	for _, value := range values {
+		if err := writer.Write(value); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	want := `"hello   world\tx"
+"  padded "
+"123"
+"true"
+"null"
+"\"quoted\""
+""
+[1,2]
+`
+	if buf.String() != want {
+		t.Errorf("Write() = %v, want %v", buf.String(), want)
+	}
+
+	reader := NewNDJSONReader(&buf, NDJSONStopOnError)
+	for _, value := range values[:len(values)-1] {
+		var got string
+		if err := reader.Next(&got); err != nil || got != value {
+			t.Errorf("Next() = %q, %v, want %q", got, err, value)
+		}
+	}
+	var items []int
+	if err := reader.Next(&items); err != nil || !reflect.DeepEqual(items, []int{1, 2}) {
+		t.Errorf("Next() = %v, %v, want [1 2]", items, err)
+	}
+}
+
+type ndjsonErrReader struct{}
+
+func (ndjsonErrReader) Read([]byte) (int, error) {
+	return 0, errors.New("broken")
+}
@@ -0,0 +1,249 @@
+package converter
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// PipelineStep is a reversible transformation of bytes, one stage of a Pipeline. Decode must undo Encode.
+type PipelineStep interface {
+	Encode(data []byte) ([]byte, error)
+	Decode(data []byte) ([]byte, error)
+}
+
+// Pipeline chains reversible steps, e.g. JSON, then gzip, then base64, so that the producer and the consumer of a
+// payload are guaranteed to apply exactly opposite transformations.
+//
+// Example:
+//
+//	pipeline := NewPipeline(GzipStep(), HMACStep(sha256.New, key), Base64Step(Base64RawURL))
+//
+//	header, err := pipeline.EncodeToString(event)
+//	if err != nil {
+//		return err
+//	}
+//
+//	var received Event
+//	err = pipeline.Decode(header, &received)
+type Pipeline struct {
+	steps []PipelineStep
+}
+
+// NewPipeline returns a pipeline that applies the given steps in order when encoding and in reverse order when
+// decoding.
+func NewPipeline(steps ...PipelineStep) *Pipeline {
+	return &Pipeline{steps: steps}
+}
+
+// Encode converts the given value to bytes using ToBytesWithErr, which marshals maps, slices and structs as JSON,
+// and then applies every step in order.
+func (p *Pipeline) Encode(a any) ([]byte, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range p.steps {
+		if bs, err = step.Encode(bs); err != nil {
+			return nil, fmt.Errorf("error encode pipeline step %d: %w", i, err)
+		}
+	}
+	return bs, nil
+}
+
+// EncodeToString is like Encode but returns the result as a string, typically used when the last step is a text
+// encoding such as Base64Step or HexStep.
+func (p *Pipeline) EncodeToString(a any) (string, error) {
+	bs, err := p.Encode(a)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// Decode converts the given data to bytes using ToBytesWithErr, undoes every step in reverse order and converts
+// the result into dest using ToDestWithErr.
+func (p *Pipeline) Decode(data, dest any) error {
+	bs, err := ToBytesWithErr(data)
+	if err != nil {
+		return err
+	}
+	for i := len(p.steps) - 1; i >= 0; i-- {
+		if bs, err = p.steps[i].Decode(bs); err != nil {
+			return fmt.Errorf("error decode pipeline step %d: %w", i, err)
+		}
+	}
+	return ToDestWithErr(bs, dest)
+}
+
+// NewPipelineStep returns a step built from a pair of functions, where decode must undo encode.
+func NewPipelineStep(encode, decode func(data []byte) ([]byte, error)) PipelineStep {
+	return pipelineStepFunc{encode: encode, decode: decode}
+}
+
+// DefaultMaxDecompressedSize is the maximum size of the data decompressed by GzipStep, ZlibStep and FlateStep, which
+// protects decoders from small payloads that expand to exhaust the memory.
+const DefaultMaxDecompressedSize = 64 << 20
+
+// GzipStep returns a step that compresses with gzip when encoding and decompresses when decoding, failing if the
+// decompressed data exceeds DefaultMaxDecompressedSize.
+func GzipStep() PipelineStep {
+	return GzipStepWithLimit(DefaultMaxDecompressedSize)
+}
+
+// GzipStepWithLimit is like GzipStep, but fails when the decompressed data exceeds maxSize bytes. A maxSize of zero
+// or less disables the limit.
+func GzipStepWithLimit(maxSize int64) PipelineStep {
+	return compressStep{
+		newWriter: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+		newReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+		maxSize:   maxSize,
+	}
+}
+
+// ZlibStep returns a step that compresses with zlib when encoding and decompresses when decoding, failing if the
+// decompressed data exceeds DefaultMaxDecompressedSize.
+func ZlibStep() PipelineStep {
+	return ZlibStepWithLimit(DefaultMaxDecompressedSize)
+}
+
+// ZlibStepWithLimit is like ZlibStep, but fails when the decompressed data exceeds maxSize bytes. A maxSize of zero
+// or less disables the limit.
+func ZlibStepWithLimit(maxSize int64) PipelineStep {
+	return compressStep{
+		newWriter: func(w io.Writer) (io.WriteCloser, error) { return zlib.NewWriter(w), nil },
+		newReader: func(r io.Reader) (io.ReadCloser, error) { return zlib.NewReader(r) },
+		maxSize:   maxSize,
+	}
+}
+
+// FlateStep returns a step that compresses with raw DEFLATE when encoding and decompresses when decoding, failing
+// if the decompressed data exceeds DefaultMaxDecompressedSize.
+func FlateStep() PipelineStep {
+	return FlateStepWithLimit(DefaultMaxDecompressedSize)
+}
+
+// FlateStepWithLimit is like FlateStep, but fails when the decompressed data exceeds maxSize bytes. A maxSize of
+// zero or less disables the limit.
+func FlateStepWithLimit(maxSize int64) PipelineStep {
+	return compressStep{
+		newWriter: func(w io.Writer) (io.WriteCloser, error) { return flate.NewWriter(w, flate.DefaultCompression) },
+		newReader: func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+		maxSize:   maxSize,
+	}
+}
+
+// Base64Step returns a step that encodes to base64 in the given variant and decodes from it.
+func Base64Step(variant Base64Variant) PipelineStep {
+	return NewPipelineStep(
+		func(data []byte) ([]byte, error) {
+			s, err := ToBase64VariantWithErr(data, variant)
+			return []byte(s), err
+		},
+		func(data []byte) ([]byte, error) {
+			return FromBase64VariantWithErr(data, variant)
+		},
+	)
+}
+
+// HexStep returns a step that encodes to hexadecimal and decodes from it.
+func HexStep() PipelineStep {
+	return NewPipelineStep(
+		func(data []byte) ([]byte, error) {
+			s, err := ToHexWithErr(data)
+			return []byte(s), err
+		},
+		func(data []byte) ([]byte, error) {
+			return FromHexWithErr(data)
+		},
+	)
+}
+
+// HMACStep returns a step that appends an HMAC tag of the data, computed with the given hash and key, when
+// encoding. When decoding, it verifies and removes the tag, failing if the data was tampered with.
+//
+// Example:
+//
+//	step := HMACStep(sha256.New, []byte("secret"))
+func HMACStep(h func() hash.Hash, key []byte) PipelineStep {
+	sign := func(data []byte) []byte {
+		mac := hmac.New(h, key)
+		mac.Write(data)
+		return mac.Sum(nil)
+	}
+	return NewPipelineStep(
+		func(data []byte) ([]byte, error) {
+			return append(bytes.Clone(data), sign(data)...), nil
+		},
+		func(data []byte) ([]byte, error) {
+			size := h().Size()
+			if len(data) < size {
+				return nil, errors.New("error verify hmac, data is shorter than the tag")
+			}
+			payload, tag := data[:len(data)-size], data[len(data)-size:]
+			if !hmac.Equal(tag, sign(payload)) {
+				return nil, errors.New("error verify hmac, tag does not match")
+			}
+			return payload, nil
+		},
+	)
+}
+
+type pipelineStepFunc struct {
+	encode func(data []byte) ([]byte, error)
+	decode func(data []byte) ([]byte, error)
+}
+
+func (s pipelineStepFunc) Encode(data []byte) ([]byte, error) {
+	return s.encode(data)
+}
+
+func (s pipelineStepFunc) Decode(data []byte) ([]byte, error) {
+	return s.decode(data)
+}
+
+type compressStep struct {
+	newWriter func(w io.Writer) (io.WriteCloser, error)
+	newReader func(r io.Reader) (io.ReadCloser, error)
+	maxSize   int64
+}
+
+func (s compressStep) Encode(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := s.newWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s compressStep) Decode(data []byte) ([]byte, error) {
+	r, err := s.newReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	if s.maxSize <= 0 {
+		return io.ReadAll(r)
+	}
+
+	bs, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	} else if int64(len(bs)) > s.maxSize {
+		return nil, fmt.Errorf("error decompress, data exceeds the limit of %d bytes", s.maxSize)
+	}
+	return bs, nil
+}
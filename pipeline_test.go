@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type pipelineEvent struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+func TestPipeline(t *testing.T) {
+	key := []byte("secret")
+	event := pipelineEvent{ID: 42, Name: strings.Repeat("event ", 20), Labels: []string{"a", "b"}}
+
+	testCases := []struct {
+		name  string
+		steps []PipelineStep
+	}{
+		{"Empty", nil},
+		{"Gzip base64", []PipelineStep{GzipStep(), Base64Step(Base64Std)}},
+		{"Zlib hex", []PipelineStep{ZlibStep(), HexStep()}},
+		{"Flate hmac raw url", []PipelineStep{FlateStep(), HMACStep(sha256.New, key), Base64Step(Base64RawURL)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pipeline := NewPipeline(tc.steps...)
+
+			encoded, err := pipeline.EncodeToString(event)
+			if err != nil {
+				t.Fatalf("EncodeToString() error = %v", err)
+			}
+
+			var got pipelineEvent
+			if err = pipeline.Decode(encoded, &got); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if ToString(got) != ToString(event) {
+				t.Errorf("Decode() = %+v, want %+v", got, event)
+			}
+		})
+	}
+}
+
+func TestPipelineHMACTampered(t *testing.T) {
+	pipeline := NewPipeline(HMACStep(sha256.New, []byte("secret")), Base64Step(Base64Std))
+
+	encoded, err := pipeline.Encode("payload")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	other := NewPipeline(HMACStep(sha256.New, []byte("other")), Base64Step(Base64Std))
+	var got string
+	if err = other.Decode(encoded, &got); err == nil {
+		t.Error("Decode() expected error for wrong key")
+	}
+	if err = pipeline.Decode(ToBase64("short"), &got); err == nil {
+		t.Error("Decode() expected error for short data")
+	}
+}
+
+func TestCompressStepLimit(t *testing.T) {
+	data := []byte(strings.Repeat("a", 1<<20))
+
+	testCases := []struct {
+		name      string
+		limited   PipelineStep
+		unlimited PipelineStep
+	}{
+		{"Gzip", GzipStepWithLimit(1024), GzipStepWithLimit(0)},
+		{"Zlib", ZlibStepWithLimit(1024), ZlibStepWithLimit(-1)},
+		{"Flate", FlateStepWithLimit(1024), FlateStepWithLimit(0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			compressed, err := tc.limited.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if _, err = tc.limited.Decode(compressed); err == nil {
+				t.Error("Decode() expected error above the limit")
+			}
+			if decoded, err := tc.unlimited.Decode(compressed); err != nil || len(decoded) != len(data) {
+				t.Errorf("Decode() = %d bytes, %v, want %d bytes", len(decoded), err, len(data))
+			}
+
+			small, _ := tc.limited.Encode(data[:1024])
+			if decoded, err := tc.limited.Decode(small); err != nil || len(decoded) != 1024 {
+				t.Errorf("Decode() = %d bytes, %v, want 1024 bytes", len(decoded), err)
+			}
+		})
+	}
+}
+
+func TestNewPipelineStep(t *testing.T) {
+	failing := NewPipelineStep(
+		func(data []byte) ([]byte, error) { return data, nil },
+		func(data []byte) ([]byte, error) { return nil, errors.New("boom") },
+	)
+	pipeline := NewPipeline(failing)
+
+	encoded, err := pipeline.Encode(10)
+	if err != nil || string(encoded) != "10" {
+		t.Fatalf("Encode() = %q, %v", encoded, err)
+	}
+	var got int
+	if err = pipeline.Decode(encoded, &got); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Decode() error = %v, want boom", err)
+	}
+}
@@ -0,0 +1,257 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// propertiesTags are the struct tags that name fields in .properties files.
+var propertiesTags = []string{"properties"}
+
+// ToPropertiesMap parses the given .properties content, panicking if parsing fails. See ToPropertiesMapWithErr.
+func ToPropertiesMap(a any) map[string]string {
+	m, err := ToPropertiesMapWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToPropertiesMapWithErr converts the given value to a string using ToStringWithErr and parses it as a Java
+// .properties file, returning its entries.
+//
+// The file follows the format of java.util.Properties:
+//   - Lines whose first non blank character is "#" or "!" are comments.
+//   - The key ends at the first unescaped "=", ":" or blank, which may be surrounded by blanks.
+//   - A line ending with an unescaped backslash continues on the next line, whose leading blanks are ignored.
+//   - Keys and values accept the escapes \t, \n, \r, \f and \uXXXX, and a backslash before any other character
+//     yields the character itself, e.g. "\=" or "\ ".
+//
+// The content is read as UTF-8, so files in ISO-8859-1 should be decoded with ToStringFromCharsetWithErr first.
+//
+// Parameters:
+//   - a: The .properties content, usually a string or a byte slice.
+//
+// Returns:
+//   - map[string]string: The entries of the file.
+//   - error: An error, with the line number, is returned in case of failure to parse.
+//
+// Example:
+//
+//	m, _ := ToPropertiesMapWithErr("# server\nserver.port = 8080\nserver.name: api \\\n    v2")
+//	fmt.Println(m) // map[server.name:api v2 server.port:8080]
+func ToPropertiesMapWithErr(a any) (map[string]string, error) {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	s = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(string(StripBOM([]byte(s))))
+	lines := strings.Split(s, "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		for isPropertiesContinued(line) {
+			line = line[:len(line)-1]
+			if i++; i >= len(lines) {
+				break
+			}
+			line += strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		end := 0
+		for ; end < len(line) && !strings.ContainsRune("=: \t\f", rune(line[end])); end++ {
+			if line[end] == '\\' {
+				end++
+			}
+		}
+		end = min(end, len(line))
+		rest := strings.TrimLeft(line[end:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+
+		key, err := unescapeProperties(line[:end])
+		if err != nil {
+			return nil, fmt.Errorf("error convert from properties, line %d, %w", lineNumber, err)
+		}
+		value, err := unescapeProperties(rest)
+		if err != nil {
+			return nil, fmt.Errorf("error convert from properties, line %d, %w", lineNumber, err)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// ToDestFromProperties parses the given .properties content into dest, panicking if the conversion fails.
+// See ToDestFromPropertiesWithErr.
+func ToDestFromProperties(a, dest any) {
+	if err := ToDestFromPropertiesWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// ToDestFromPropertiesWithErr parses the given .properties content with ToPropertiesMapWithErr and fills dest with
+// its entries, converting each one with the rules of ToDestWithErr.
+//
+// Keys are split by "." into nested structs and maps, e.g. "db.host" fills the field Host of the field DB. Struct
+// fields are named by their `properties` tag or by their name, and matched case insensitively. Slices are filled
+// from comma separated values. Fields without an entry are left unchanged, so defaults may be set before the call.
+// A *map[string]string destination receives the entries as they are.
+//
+// A key that also has nested keys, such as "logging.level" next to "logging.level.root", fills a string field
+// with its value, is skipped for a struct field, and is kept under the key "" of a map field.
+//
+// Example:
+//
+//	type Config struct {
+//		DB struct {
+//			Host string `properties:"host"`
+//			Port int    `properties:"port"`
+//		} `properties:"db"`
+//	}
+//	var config Config
+//	err := ToDestFromPropertiesWithErr("db.host=localhost\ndb.port=5432", &config)
+//	fmt.Println(config, err) // {{localhost 5432}} <nil>
+func ToDestFromPropertiesWithErr(a, dest any) error {
+	values, err := ToPropertiesMapWithErr(a)
+	if err != nil {
+		return err
+	}
+	return weakDecodeMap(values, dest, ".", weakDecoder{tags: propertiesTags, separator: ","})
+}
+
+// ToPropertiesString converts the given struct or map to .properties content, panicking if the conversion fails.
+// See ToPropertiesStringWithErr.
+func ToPropertiesString(a any) string {
+	s, err := ToPropertiesStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToPropertiesStringWithErr converts the given struct or map to .properties content that
+// ToDestFromPropertiesWithErr reads back.
+//
+// Struct fields are named by their `properties` tag or by their name, zero fields with the omitempty option and nil
+// pointers are skipped, and nested structs and maps have their keys joined by ".". Values are rendered with
+// ToStringWithErr, slices are joined by commas, and keys and values are escaped as needed. Struct fields keep their
+// order, while map keys are sorted.
+//
+// Example:
+//
+//	s, _ := ToPropertiesStringWithErr(map[string]any{"db": map[string]any{"host": "localhost", "port": 5432}})
+//	fmt.Print(s)
+//	// db.host=localhost
+//	// db.port=5432
+func ToPropertiesStringWithErr(a any) (string, error) {
+	entries, err := weakFlattenStructOrMap(a, "properties", propertiesTags, ",")
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	for _, entry := range entries {
+		builder.WriteString(escapeProperties(strings.Join(entry.path, "."), true))
+		builder.WriteByte('=')
+		builder.WriteString(escapeProperties(entry.value, false))
+		builder.WriteByte('\n')
+	}
+	return builder.String(), nil
+}
+
+func isPropertiesContinued(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+func unescapeProperties(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			builder.WriteByte(s[i])
+			continue
+		} else if i++; i >= len(s) {
+			break
+		}
+
+		switch s[i] {
+		case 't':
+			builder.WriteByte('\t')
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'u':
+			r, err := parsePropertiesUnicode(s[i+1:])
+			if err != nil {
+				return "", err
+			}
+			i += 4
+			if utf16.IsSurrogate(r) && strings.HasPrefix(s[i+1:], `\u`) {
+				if low, err := parsePropertiesUnicode(s[i+3:]); err == nil {
+					r = utf16.DecodeRune(r, low)
+					i += 6
+				}
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteByte(s[i])
+		}
+	}
+	return builder.String(), nil
+}
+
+func parsePropertiesUnicode(s string) (rune, error) {
+	if len(s) < 4 {
+		return 0, fmt.Errorf("malformed \\u escape %q", s)
+	}
+	u, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("malformed \\u escape %q", s[:4])
+	}
+	return rune(u), nil
+}
+
+func escapeProperties(s string, key bool) string {
+	var builder strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '=', ':', '#', '!', ' ':
+			if key || i == 0 {
+				builder.WriteByte('\\')
+			}
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,143 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+)
+
+type propertiesTestConfig struct {
+	Server struct {
+		Port int    `properties:"port"`
+		Name string `properties:"name"`
+	} `properties:"server"`
+	Profiles []string          `properties:"profiles"`
+	Labels   map[string]string `properties:"labels"`
+}
+
+func TestToPropertiesMapWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "Separators and comments",
+			input: "# comment\n! other\nserver.port = 8080\nserver.name:api\nflag\ncolor red \n  indented=yes",
+			want: map[string]string{
+				"server.port": "8080", "server.name": "api", "flag": "", "color": "red ", "indented": "yes",
+			},
+		},
+		{
+			name:  "Continuation lines",
+			input: "fruits = apple, \\\n         banana\r\npath=c:\\\\dir\\\\\nnext=1",
+			want:  map[string]string{"fruits": "apple, banana", "path": `c:\dir\`, "next": "1"},
+		},
+		{
+			name:  "Escapes",
+			input: "key\\ with\\=sep = tab\\there\\n\nunicode=\\u00e9t\\u00E9 \\uD83D\\uDE00\nother=\\q",
+			want:  map[string]string{"key with=sep": "tab\there\n", "unicode": "été 😀", "other": "q"},
+		},
+		{name: "Malformed unicode", input: "a=\\u00zz", wantErr: true},
+		{name: "Short unicode", input: "a=\\u00", wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToPropertiesMapWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToPropertiesMapWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToPropertiesMapWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDestFromPropertiesWithErr(t *testing.T) {
+	var got propertiesTestConfig
+	input := "server.port=8080\nserver.Name=api\nprofiles=dev, local\nlabels.team=core\nlabels.tier=1"
+	if err := ToDestFromPropertiesWithErr(input, &got); err != nil {
+		t.Fatalf("ToDestFromPropertiesWithErr() error = %v", err)
+	}
+	if got.Server.Port != 8080 || got.Server.Name != "api" || !reflect.DeepEqual(got.Profiles, []string{"dev", "local"}) ||
+		!reflect.DeepEqual(got.Labels, map[string]string{"team": "core", "tier": "1"}) {
+		t.Errorf("ToDestFromPropertiesWithErr() = %+v", got)
+	}
+
+	got = propertiesTestConfig{}
+	if err := ToDestFromPropertiesWithErr("server=1\nserver.port=2", &got); err != nil || got.Server.Port != 2 {
+		t.Errorf("ToDestFromPropertiesWithErr() = %+v, %v, want the value of server skipped", got, err)
+	}
+
+	var logging struct {
+		Logging struct {
+			Level struct {
+				Root string `properties:"root"`
+			} `properties:"level"`
+		} `properties:"logging"`
+		Levels map[string]string `properties:"levels"`
+		Level  string            `properties:"level"`
+	}
+	input = "logging.level=INFO\nlogging.level.root=DEBUG\nlevels=x\nlevels.web=WARN\nlevel=TRACE\nlevel.sql=OFF"
+	if err := ToDestFromPropertiesWithErr(input, &logging); err != nil {
+		t.Fatalf("ToDestFromPropertiesWithErr() error = %v", err)
+	}
+	if logging.Logging.Level.Root != "DEBUG" || logging.Level != "TRACE" ||
+		!reflect.DeepEqual(logging.Levels, map[string]string{"": "x", "web": "WARN"}) {
+		t.Errorf("ToDestFromPropertiesWithErr() = %+v", logging)
+	}
+
+	var tree map[string]any
+	err := ToDestFromPropertiesWithErr("a=1\na.=2", &tree)
+	if err == nil || err.Error() != `error convert "a.": duplicate key ""` {
+		t.Errorf("ToDestFromPropertiesWithErr() error = %v, want a duplicate key error", err)
+	}
+	if err := ToDestFromPropertiesWithErr("server.port=abc", &got); err == nil {
+		t.Error("ToDestFromPropertiesWithErr() error = nil, want an error for an invalid number")
+	}
+}
+
+func TestToPropertiesStringWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "Map",
+			input: map[string]any{"db": map[string]any{"host": "localhost", "port": 5432}, "a key": " x=y\n"},
+			want:  "a\\ key=\\ x=y\\n\ndb.host=localhost\ndb.port=5432\n",
+		},
+		{name: "Slice", input: map[string][]int{"ids": {1, 2}}, want: "ids=1,2\n"},
+		{name: "Slice of maps", input: map[string]any{"items": []any{map[string]any{}}}, wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToPropertiesStringWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToPropertiesStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToPropertiesStringWithErr() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPropertiesRoundTrip(t *testing.T) {
+	want := map[string]string{"a=b": "c:d", " lead": "  spaced  ", "path": `c:\dir`, "é": "ü\t"}
+
+	var got map[string]string
+	if err := ToDestFromPropertiesWithErr(ToPropertiesString(want), &got); err != nil {
+		t.Fatalf("ToDestFromPropertiesWithErr() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var clock atomic.Value
+
+var (
+	relativeOffsetRegex = regexp.MustCompile(`^([+-])\s*((?:\d+\s*[a-z]+\s*)+)`)
+	relativeTermRegex   = regexp.MustCompile(`(\d+)\s*([a-z]+)`)
+	relativeAgoRegex    = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+(?:ago|atras)$`)
+	relativeInRegex     = regexp.MustCompile(`^(?:in|ha|daqui a|em)\s+(\d+)\s*([a-z]+)$`)
+)
+
+var relativeAnchors = []struct {
+	names  []string
+	anchor func(now time.Time) time.Time
+}{
+	{[]string{"now", "agora"}, func(now time.Time) time.Time { return now }},
+	{[]string{"today", "hoje"}, startOfDay},
+	{[]string{"yesterday", "ontem"}, func(now time.Time) time.Time { return startOfDay(now).AddDate(0, 0, -1) }},
+	{[]string{"tomorrow", "amanha"}, func(now time.Time) time.Time { return startOfDay(now).AddDate(0, 0, 1) }},
+	{[]string{"start of day", "beginning of day", "inicio do dia"}, startOfDay},
+	{[]string{"start of week", "beginning of week", "inicio da semana"}, startOfWeek},
+	{[]string{"start of month", "beginning of month", "inicio do mes"}, startOfMonth},
+	{[]string{"start of year", "beginning of year", "inicio do ano"}, startOfYear},
+	{[]string{"end of day", "fim do dia", "final do dia"}, func(now time.Time) time.Time {
+		return startOfDay(now).AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}},
+	{[]string{"end of week", "fim da semana", "final da semana"}, func(now time.Time) time.Time {
+		return startOfWeek(now).AddDate(0, 0, 7).Add(-time.Nanosecond)
+	}},
+	{[]string{"end of month", "fim do mes", "final do mes"}, func(now time.Time) time.Time {
+		return startOfMonth(now).AddDate(0, 1, 0).Add(-time.Nanosecond)
+	}},
+	{[]string{"end of year", "fim do ano", "final do ano"}, func(now time.Time) time.Time {
+		return startOfYear(now).AddDate(1, 0, 0).Add(-time.Nanosecond)
+	}},
+}
+
+// SetClock replaces the function used to obtain the current time when evaluating relative time expressions in
+// ToTimeLenientWithErr and ToDestWithErr. Passing nil restores time.Now.
+//
+// Tests use it to make relative expressions deterministic:
+//
+//	SetClock(func() time.Time { return time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC) })
+//	defer SetClock(nil)
+func SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	clock.Store(now)
+}
+
+// Clock returns the current time according to the clock configured with SetClock.
+func Clock() time.Time {
+	if now, ok := clock.Load().(func() time.Time); ok {
+		return now()
+	}
+	return time.Now()
+}
+
+// CouldBeRelativeTime checks if the given value is a relative time expression understood by
+// ParseRelativeTimeWithErr.
+//
+// Example:
+//
+//	fmt.Println(CouldBeRelativeTime("now-2h"))     // true
+//	fmt.Println(CouldBeRelativeTime("2026-10-17")) // false
+func CouldBeRelativeTime(a any) bool {
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return false
+	}
+	_, err = ParseRelativeTimeWithErr(s, time.Time{})
+	return err == nil
+}
+
+// ParseRelativeTimeWithErr evaluates a relative or natural-language time expression against the given instant.
+// The input is case and accent insensitive.
+//
+// Supported expressions:
+//   - Anchors: "now", "today", "yesterday", "tomorrow", "start of day|week|month|year",
+//     "end of day|week|month|year" and their pt-BR forms "agora", "hoje", "ontem", "amanhã",
+//     "início do dia|da semana|do mês|do ano" and "fim do dia|da semana|do mês|do ano". Weeks start on Monday.
+//   - Offsets: one or more signed terms such as "+3d", "-2h30m" or "- 1 week", optionally following an anchor, as
+//     in "now-2h" or "today+1d". Without an anchor the offset is applied to 'now'.
+//   - Phrases: "2 hours ago", "in 3 days", "2 dias atrás", "há 2 dias" and "daqui a 3 dias".
+//
+// Units: ms, s, m (minute), h, d, w, mo (month) and y, as well as their English and Portuguese names in singular
+// and plural, e.g. "minutes", "horas", "mês" or "anos".
+//
+// Example:
+//
+//	now := time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC)
+//	t, _ := ParseRelativeTimeWithErr("today-1d", now)
+//	fmt.Println(t) // 2026-10-16 00:00:00 +0000 UTC
+func ParseRelativeTimeWithErr(s string, now time.Time) (time.Time, error) {
+	expr := strings.Join(strings.Fields(foldAccents(strings.ToLower(s))), " ")
+	if expr == "" {
+		return time.Time{}, errors.New("error parse relative time, it is empty")
+	}
+
+	if match := relativeAgoRegex.FindStringSubmatch(expr); match != nil {
+		return addRelativeTerm(now, -1, match[1], match[2], s)
+	} else if match = relativeInRegex.FindStringSubmatch(expr); match != nil {
+		sign := 1
+		if strings.HasPrefix(expr, "ha ") {
+			sign = -1
+		}
+		return addRelativeTerm(now, sign, match[1], match[2], s)
+	}
+
+	t, rest, found := now, expr, false
+	for _, anchor := range relativeAnchors {
+		for _, name := range anchor.names {
+			if strings.HasPrefix(rest, name) && (len(rest) == len(name) || !isLetter(rest[len(name)])) {
+				t, rest, found = anchor.anchor(now), strings.TrimSpace(rest[len(name):]), true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+	if !found && !strings.HasPrefix(rest, "+") && !strings.HasPrefix(rest, "-") {
+		return time.Time{}, fmt.Errorf("error parse relative time: unknown expression \"%s\"", s)
+	}
+
+	for rest != "" {
+		match := relativeOffsetRegex.FindStringSubmatch(rest)
+		if match == nil {
+			return time.Time{}, fmt.Errorf("error parse relative time: unknown expression \"%s\"", s)
+		}
+		sign := 1
+		if match[1] == "-" {
+			sign = -1
+		}
+		for _, term := range relativeTermRegex.FindAllStringSubmatch(match[2], -1) {
+			var err error
+			if t, err = addRelativeTerm(t, sign, term[1], term[2], s); err != nil {
+				return time.Time{}, err
+			}
+		}
+		rest = strings.TrimSpace(rest[len(match[0]):])
+	}
+
+	return t, nil
+}
+
+// ToTimeLenient converts the given value to a time.Time using ToTimeLenientWithErr, panicking if the conversion
+// fails.
+func ToTimeLenient(a any) time.Time {
+	t, err := ToTimeLenientWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ToTimeLenientWithErr converts the given value to a time.Time like ToTimeWithErr, additionally accepting the
+// relative expressions understood by ParseRelativeTimeWithErr. Relative expressions are evaluated against the
+// clock configured with SetClock.
+//
+// Example:
+//
+//	t, err := ToTimeLenientWithErr("start of month")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(t) // 2026-10-01 00:00:00 ...
+func ToTimeLenientWithErr(a any) (time.Time, error) {
+	t, err := ToTimeWithErr(a)
+	if err == nil {
+		return t, nil
+	}
+
+	reflectValue := reflect.ValueOf(a)
+	for reflectValue.Kind() == reflect.Pointer || reflectValue.Kind() == reflect.Interface {
+		if reflectValue.IsNil() {
+			return time.Time{}, err
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	if reflectValue.Kind() != reflect.String {
+		return time.Time{}, err
+	}
+
+	t, relativeErr := ParseRelativeTimeWithErr(reflectValue.String(), Clock())
+	if relativeErr != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+func addRelativeTerm(t time.Time, sign int, amountText, unit, expr string) (time.Time, error) {
+	amount, err := strconv.Atoi(amountText)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parse relative time: invalid amount \"%s\"", amountText)
+	}
+	amount *= sign
+
+	switch unit {
+	case "ms", "millisecond", "milliseconds", "milissegundo", "milissegundos":
+		return t.Add(time.Duration(amount) * time.Millisecond), nil
+	case "s", "sec", "secs", "second", "seconds", "seg", "segundo", "segundos":
+		return t.Add(time.Duration(amount) * time.Second), nil
+	case "m", "min", "mins", "minute", "minutes", "minuto", "minutos":
+		return t.Add(time.Duration(amount) * time.Minute), nil
+	case "h", "hr", "hrs", "hour", "hours", "hora", "horas":
+		return t.Add(time.Duration(amount) * time.Hour), nil
+	case "d", "day", "days", "dia", "dias":
+		return t.AddDate(0, 0, amount), nil
+	case "w", "week", "weeks", "semana", "semanas":
+		return t.AddDate(0, 0, 7*amount), nil
+	case "mo", "month", "months", "mes", "meses":
+		return t.AddDate(0, amount, 0), nil
+	case "y", "year", "years", "ano", "anos":
+		return t.AddDate(amount, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("error parse relative time: unknown unit \"%s\" in \"%s\"", unit, expr)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func startOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+func foldAccents(s string) string {
+	return accentReplacer.Replace(s)
+}
@@ -0,0 +1,89 @@
+package converter
+
+import (
+	"testing"
+	"time"
+)
+
+var relativeNow = time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC)
+
+func TestParseRelativeTimeWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"Now", "now", relativeNow, false},
+		{"Today", "Today", time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC), false},
+		{"Yesterday", "yesterday", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), false},
+		{"Hoje", "hoje", time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC), false},
+		{"Ontem", "ontem", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), false},
+		{"Amanha", "Amanhã", time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC), false},
+		{"Now minus hours", "now-2h", relativeNow.Add(-2 * time.Hour), false},
+		{"Offset only", "+3d", relativeNow.AddDate(0, 0, 3), false},
+		{"Compound offset", "now - 1h30m", relativeNow.Add(-90 * time.Minute), false},
+		{"Chained offsets", "today+1d-2h", time.Date(2026, time.October, 17, 22, 0, 0, 0, time.UTC), false},
+		{"Start of month", "start of month", time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), false},
+		{"Inicio do mes", "início do mês", time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), false},
+		{"Start of week", "start of week", time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC), false},
+		{"End of month", "end of month", time.Date(2026, time.November, 1, 0, 0, 0, -1, time.UTC), false},
+		{"Ago", "2 days ago", relativeNow.AddDate(0, 0, -2), false},
+		{"In", "in 3 weeks", relativeNow.AddDate(0, 0, 21), false},
+		{"Ha", "há 2 meses", relativeNow.AddDate(0, -2, 0), false},
+		{"Unknown unit", "now+3x", time.Time{}, true},
+		{"Unknown expression", "someday", time.Time{}, true},
+		{"Empty", " ", time.Time{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRelativeTimeWithErr(tc.input, relativeNow)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ParseRelativeTimeWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("ParseRelativeTimeWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToTimeLenientWithErr(t *testing.T) {
+	SetClock(func() time.Time { return relativeNow })
+	defer SetClock(nil)
+
+	got, err := ToTimeLenientWithErr("now-2h")
+	if err != nil {
+		t.Fatalf("ToTimeLenientWithErr() error = %v", err)
+	}
+	if want := relativeNow.Add(-2 * time.Hour); !got.Equal(want) {
+		t.Errorf("ToTimeLenientWithErr() = %v, want %v", got, want)
+	}
+
+	got, err = ToTimeLenientWithErr("2026-10-17")
+	if err != nil {
+		t.Fatalf("ToTimeLenientWithErr() error = %v", err)
+	}
+	if want := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ToTimeLenientWithErr() = %v, want %v", got, want)
+	}
+
+	if _, err = ToTimeLenientWithErr("not a time"); err == nil {
+		t.Error("ToTimeLenientWithErr() expected error")
+	}
+}
+
+func TestToDestWithErrRelativeTime(t *testing.T) {
+	SetClock(func() time.Time { return relativeNow })
+	defer SetClock(nil)
+
+	var dest time.Time
+	if err := ToDestWithErr("yesterday", &dest); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if want := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC); !dest.Equal(want) {
+		t.Errorf("ToDestWithErr() = %v, want %v", dest, want)
+	}
+}
@@ -84,6 +84,8 @@ func ToString(a any) string {
 //   - Arrays and Slices: If an element type is uint8, converts the byte slice to a string. For other types, marshals
 //     the value to JSON.
 //   - Maps and Structs: Marshals the value to JSON.
+//   - time.Time: Formats the value with DefaultTimeLayout, RFC 3339 with nanoseconds.
+//   - Types registered with RegisterFlags: Renders the names of the set bits joined by "|".
 //   - Pointers and Interfaces: If the value is nil, returns an error. Otherwise, attempts to convert the element value
 //     to a string.
 //   - Other types: Returns an error indicating an unsupported type.
@@ -210,7 +212,7 @@ func ToCompactString(a any) string {
 //
 // The compact form of a string is one where multiple consecutive white spaces
 // get replaced by a single space. The function utilizes regular expressions
-// to achieve this.
+// to achieve this. JSON5 documents are compacted as strict JSON by ToCompactStringLenientWithErr.
 //
 // Parameters:
 //   - a: The value of any type to be converted to a compact string.
@@ -261,6 +263,39 @@ func ToCompactStringWithErr(a any) (string, error) {
 	return s, nil
 }
 
+// ToCompactStringLenient converts the given value to a compact string, reading JSON5, panicking if the conversion
+// fails. See ToCompactStringLenientWithErr.
+func ToCompactStringLenient(a any) string {
+	s, err := ToCompactStringLenientWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToCompactStringLenientWithErr is like ToCompactStringWithErr, but strings and byte slices holding a JSON5 object
+// or array, with comments, trailing commas or single quotes, are compacted as strict JSON, see ToStrictJSONWithErr.
+// Text that is not valid JSON5, such as log lines starting with "[WARN]", has its whitespace compacted as any
+// other text.
+//
+// Example:
+//
+//	s, _ := ToCompactStringLenientWithErr("/* hosts */ ['a',\n 'b',]")
+//	fmt.Println(s) // ["a","b"]
+func ToCompactStringLenientWithErr(a any) (string, error) {
+	if isTextSource(a) {
+		bs, err := ToBytesWithErr(a)
+		if err != nil {
+			return "", err
+		} else if !json.Valid(bs) && isJSONDocumentStart(bs) {
+			if strict, err := toStrictJSON(bs); err == nil {
+				return string(strict), nil
+			}
+		}
+	}
+	return ToCompactStringWithErr(a)
+}
+
 func implementsStringer(reflectType reflect.Type) bool {
 	if reflectType == nil {
 		return false
@@ -283,7 +318,11 @@ func implementsError(reflectType reflect.Type) bool {
 }
 
 func resolveStringImplementsIfPresent(reflectType reflect.Type, reflectValue reflect.Value) (string, error) {
-	if implementsStringer(reflectType) {
+	if t, ok := timeIfPresent(reflectValue); ok {
+		return t.Format(DefaultTimeLayout), nil
+	} else if set, ok := lookupFlagSet(reflectType); ok {
+		return set.format(reflectValue), nil
+	} else if implementsStringer(reflectType) {
 		return reflectValue.Interface().(fmt.Stringer).String(), nil
 	} else if implementsMarshaler(reflectType) {
 		marshal, err := json.Marshal(reflectValue.Interface())
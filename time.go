@@ -1,11 +1,31 @@
 package converter
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"time"
 )
 
+// DefaultTimeLayout is the layout used by ToStringWithErr to render time.Time values. It is understood by
+// ToTimeWithErr, so both conversions round trip. Use ToTimeStringWithErr for other layouts.
+const DefaultTimeLayout = time.RFC3339Nano
+
+// DefaultEpochUnit is the unit of numeric epochs used by ToTimeWithErr and by the numeric conversions of time.Time.
+// Use ToTimeInUnitWithErr and ToInt64InUnitWithErr, or their variants, for other units.
+const DefaultEpochUnit = time.Millisecond
+
+// DefaultTwoDigitYearPivot is the pivot used to expand two-digit years. Years below the pivot belong to the 2000s,
+// the others to the 1900s, so "26" becomes 2026 and "85" becomes 1985. Use ToMonthYearWithPivotWithErr for another
+// pivot.
+const DefaultTwoDigitYearPivot = 70
+
+// ToTimeWithErr converts the given value to a time.Time. Strings are parsed with the standard layouts and the
+// month and weekday names of the registered locales, and numbers are read as epochs in DefaultEpochUnit.
+//
+// Relative expressions such as "now-2h" or "start of month" are not accepted here, so that the result never
+// depends on the current time. Use ToTimeLenientWithErr for them.
 func ToTimeWithErr(a any) (time.Time, error) {
 	reflectValue := reflect.ValueOf(a)
 	switch reflectValue.Kind() {
@@ -18,14 +38,24 @@ func ToTimeWithErr(a any) (time.Time, error) {
 				return t, nil
 			}
 		}
+		if t, ok := parseLocalizedTime(reflectValue.String()); ok {
+			return t, nil
+		}
 		return time.Time{}, fmt.Errorf("cannot convert string to time.Time: Unknown format \"%s\"",
 			reflectValue.String())
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return time.UnixMilli(reflectValue.Int()), nil
+		return timeFromEpoch(reflectValue.Int(), DefaultEpochUnit), nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return time.UnixMilli(int64(reflectValue.Uint())), nil
+		return timeFromEpoch(int64(reflectValue.Uint()), DefaultEpochUnit), nil
 	case reflect.Float32, reflect.Float64:
-		return time.UnixMilli(int64(reflectValue.Float())), nil
+		return timeFromEpochFloat(reflectValue.Float(), DefaultEpochUnit), nil
+	case reflect.Interface, reflect.Pointer:
+		if reflectValue.IsNil() {
+			return time.Time{}, errors.New("cannot convert to time.Time, it is null")
+		}
+		return ToTimeWithErr(reflectValue.Elem().Interface())
+	case reflect.Invalid:
+		return time.Time{}, errors.New("cannot convert to time.Time, it is null")
 	default:
 		if reflectValue.Type() == reflect.TypeOf(time.Time{}) {
 			return reflectValue.Interface().(time.Time), nil
@@ -66,8 +96,8 @@ func MonthYearToTimeFromInts(month int, year int, loc *time.Location) (time.Time
 		loc = time.UTC
 	}
 
-	if year <= 2 {
-		y4, err := normalize2DigitYearTo4(year)
+	if year >= 0 && year <= 99 {
+		y4, err := normalize2DigitYearTo4(year, DefaultTwoDigitYearPivot)
 		if err != nil {
 			return time.Time{}, err
 		}
@@ -77,9 +107,226 @@ func MonthYearToTimeFromInts(month int, year int, loc *time.Location) (time.Time
 	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc), nil
 }
 
-func normalize2DigitYearTo4(year2 int) (int, error) {
+func normalize2DigitYearTo4(year2, pivot int) (int, error) {
 	if year2 < 0 || year2 > 99 {
 		return 0, fmt.Errorf("invalid 2-digit year: %d", year2)
+	} else if year2 < pivot {
+		return 2000 + year2, nil
+	}
+	return 1900 + year2, nil
+}
+
+// ToTimeString converts the given value to a time.Time using ToTimeWithErr and formats it with the given layout,
+// panicking if the conversion fails.
+func ToTimeString(a any, layout string) string {
+	s, err := ToTimeStringWithErr(a, layout)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToTimeStringWithErr converts the given value to a time.Time using ToTimeWithErr and formats it with the given
+// layout. An empty layout means DefaultTimeLayout. Use ToLocalizedStringWithErr to render month
+// and weekday names in another language.
+//
+// Example:
+//
+//	s, _ := ToTimeStringWithErr("2026-10-17T10:30:00Z", time.DateTime)
+//	fmt.Println(s) // 2026-10-17 10:30:00
+func ToTimeStringWithErr(a any, layout string) (string, error) {
+	t, err := ToTimeWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+	return t.Format(layout), nil
+}
+
+// ToTimeInUnit converts the given value to a time.Time reading numbers as epochs in the given unit, panicking if
+// the conversion fails. See ToTimeInUnitWithErr.
+func ToTimeInUnit(a any, unit time.Duration) time.Time {
+	t, err := ToTimeInUnitWithErr(a, unit)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ToTimeInUnitWithErr converts the given value to a time.Time like ToTimeWithErr, except that numbers are read as
+// epochs in the given unit instead of DefaultEpochUnit.
+//
+// Example:
+//
+//	t, _ := ToTimeInUnitWithErr(1792195200, time.Second)
+//	fmt.Println(t.UTC()) // 2026-10-17 00:00:00 +0000 UTC
+func ToTimeInUnitWithErr(a any, unit time.Duration) (time.Time, error) {
+	if err := validateEpochUnit(unit); err != nil {
+		return time.Time{}, err
+	}
+
+	reflectValue := reflect.ValueOf(a)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return timeFromEpoch(reflectValue.Int(), unit), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return timeFromEpoch(int64(reflectValue.Uint()), unit), nil
+	case reflect.Float32, reflect.Float64:
+		return timeFromEpochFloat(reflectValue.Float(), unit), nil
+	default:
+		return ToTimeWithErr(a)
+	}
+}
+
+// ToInt64InUnit converts the given value to an int64 expressed in the given unit, panicking if the conversion
+// fails. See ToInt64InUnitWithErr.
+func ToInt64InUnit(a any, unit time.Duration) int64 {
+	i, err := ToInt64InUnitWithErr(a, unit)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// ToInt64InUnitWithErr converts the given value to an int64 expressed in the given unit. A time.Time becomes its
+// epoch in that unit and a time.Duration the number of whole units it contains. Any other value is converted with
+// ToInt64WithErr.
+//
+// Example:
+//
+//	t := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+//	fmt.Println(ToInt64InUnitWithErr(t, time.Second))           // 1792195200 <nil>
+//	fmt.Println(ToInt64InUnitWithErr(90*time.Minute, time.Hour)) // 1 <nil>
+func ToInt64InUnitWithErr(a any, unit time.Duration) (int64, error) {
+	if err := validateEpochUnit(unit); err != nil {
+		return 0, err
+	}
+	switch v := indirectValue(a).(type) {
+	case time.Time:
+		return epochFromTime(v, unit), nil
+	case time.Duration:
+		return int64(v / unit), nil
+	}
+	return ToInt64WithErr(a)
+}
+
+// ToUint64InUnit converts the given value to an uint64 expressed in the given unit, panicking if the conversion
+// fails. See ToUint64InUnitWithErr.
+func ToUint64InUnit(a any, unit time.Duration) uint64 {
+	u, err := ToUint64InUnitWithErr(a, unit)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// ToUint64InUnitWithErr converts the given value to an uint64 expressed in the given unit, following the rules of
+// ToInt64InUnitWithErr. Negative epochs and durations result in an error.
+func ToUint64InUnitWithErr(a any, unit time.Duration) (uint64, error) {
+	if err := validateEpochUnit(unit); err != nil {
+		return 0, err
+	}
+	switch indirectValue(a).(type) {
+	case time.Time, time.Duration:
+		i, err := ToInt64InUnitWithErr(a, unit)
+		if err != nil {
+			return 0, err
+		} else if i < 0 {
+			return 0, fmt.Errorf("error: trying to convert a negative int to uint: got %v", i)
+		}
+		return uint64(i), nil
+	}
+	return ToUint64WithErr(a)
+}
+
+// ToFloat64InUnit converts the given value to a float64 expressed in the given unit, panicking if the conversion
+// fails. See ToFloat64InUnitWithErr.
+func ToFloat64InUnit(a any, unit time.Duration) float64 {
+	f, err := ToFloat64InUnitWithErr(a, unit)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ToFloat64InUnitWithErr converts the given value to a float64 expressed in the given unit. A time.Time becomes its
+// epoch in that unit and a time.Duration the number of units it contains, both keeping the fractional part. Any
+// other value is converted with ToFloat64WithErr.
+//
+// Example:
+//
+//	fmt.Println(ToFloat64InUnitWithErr(90*time.Minute, time.Hour)) // 1.5 <nil>
+func ToFloat64InUnitWithErr(a any, unit time.Duration) (float64, error) {
+	if err := validateEpochUnit(unit); err != nil {
+		return 0, err
+	}
+	switch v := indirectValue(a).(type) {
+	case time.Time:
+		return epochFloatFromTime(v, unit), nil
+	case time.Duration:
+		return float64(v) / float64(unit), nil
+	}
+	return ToFloat64WithErr(a)
+}
+
+func validateEpochUnit(unit time.Duration) error {
+	if unit <= 0 || (time.Second%unit != 0 && unit%time.Second != 0) {
+		return fmt.Errorf("invalid epoch unit: %s", unit)
+	}
+	return nil
+}
+
+func timeFromEpoch(n int64, unit time.Duration) time.Time {
+	if unit >= time.Second {
+		return time.Unix(n*int64(unit/time.Second), 0)
+	}
+	perSecond := int64(time.Second / unit)
+	return time.Unix(n/perSecond, (n%perSecond)*int64(unit))
+}
+
+func timeFromEpochFloat(f float64, unit time.Duration) time.Time {
+	seconds, fraction := math.Modf(f * float64(unit) / float64(time.Second))
+	return time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+}
+
+func epochFromTime(t time.Time, unit time.Duration) int64 {
+	if unit >= time.Second {
+		return t.Unix() / int64(unit/time.Second)
+	}
+	return t.Unix()*int64(time.Second/unit) + int64(t.Nanosecond())/int64(unit)
+}
+
+func epochFloatFromTime(t time.Time, unit time.Duration) float64 {
+	return float64(t.Unix())*float64(time.Second)/float64(unit) + float64(t.Nanosecond())/float64(unit)
+}
+
+func timeIfPresent(reflectValue reflect.Value) (time.Time, bool) {
+	if !reflectValue.IsValid() || !reflectValue.CanInterface() {
+		return time.Time{}, false
+	}
+	switch v := reflectValue.Interface().(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func indirectValue(a any) any {
+	reflectValue := reflect.ValueOf(a)
+	for reflectValue.Kind() == reflect.Pointer || reflectValue.Kind() == reflect.Interface {
+		if reflectValue.IsNil() {
+			return nil
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	if !reflectValue.IsValid() {
+		return nil
 	}
-	return 2000 + year2, nil
+	return reflectValue.Interface()
 }
@@ -0,0 +1,166 @@
+package converter
+
+import (
+	"testing"
+	"time"
+)
+
+var timeTestValue = time.Date(2026, time.October, 17, 10, 30, 15, 123456789, time.UTC)
+
+func TestToStringWithErrTime(t *testing.T) {
+	got, err := ToStringWithErr(timeTestValue)
+	if err != nil {
+		t.Fatalf("ToStringWithErr() error = %v", err)
+	}
+	if want := "2026-10-17T10:30:15.123456789Z"; got != want {
+		t.Errorf("ToStringWithErr() = %q, want %q", got, want)
+	}
+
+	back, err := ToTimeWithErr(got)
+	if err != nil {
+		t.Fatalf("ToTimeWithErr() error = %v", err)
+	}
+	if !back.Equal(timeTestValue) {
+		t.Errorf("ToTimeWithErr() = %v, want %v", back, timeTestValue)
+	}
+}
+
+func TestToTimeStringWithErr(t *testing.T) {
+	got, err := ToTimeStringWithErr("2026-10-17T10:30:00Z", time.DateTime)
+	if err != nil {
+		t.Fatalf("ToTimeStringWithErr() error = %v", err)
+	}
+	if want := "2026-10-17 10:30:00"; got != want {
+		t.Errorf("ToTimeStringWithErr() = %q, want %q", got, want)
+	}
+}
+
+func TestTimeToNumbers(t *testing.T) {
+	millis := timeTestValue.UnixMilli()
+
+	if got := ToInt64(timeTestValue); got != millis {
+		t.Errorf("ToInt64() = %d, want %d", got, millis)
+	}
+	if got := ToUint64(timeTestValue); got != uint64(millis) {
+		t.Errorf("ToUint64() = %d, want %d", got, millis)
+	}
+	if got := ToFloat64(timeTestValue); got < float64(millis) || got >= float64(millis+1) {
+		t.Errorf("ToFloat64() = %f, want %d.x", got, millis)
+	}
+	if got := ToTime(ToInt64(timeTestValue)); !got.Equal(timeTestValue.Truncate(time.Millisecond)) {
+		t.Errorf("ToTime(ToInt64()) = %v, want %v", got, timeTestValue.Truncate(time.Millisecond))
+	}
+	if _, err := ToUintWithErr(time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("ToUintWithErr() expected error for negative epoch")
+	}
+}
+
+func TestDefaultEpochUnit(t *testing.T) {
+	seconds := timeTestValue.Unix()
+	if got := ToTimeInUnit(seconds, time.Second); !got.Equal(timeTestValue.Truncate(time.Second)) {
+		t.Errorf("ToTimeInUnit() = %v, want %v", got, timeTestValue.Truncate(time.Second))
+	}
+	if got := ToTimeInUnit(1.5, time.Second); !got.Equal(time.Unix(1, 500000000)) {
+		t.Errorf("ToTimeInUnit() = %v, want %v", got, time.Unix(1, 500000000))
+	}
+	if got := ToTime(seconds); !got.Equal(time.UnixMilli(seconds)) {
+		t.Errorf("ToTime() = %v, want %v", got, time.UnixMilli(seconds))
+	}
+	if _, err := ToTimeInUnitWithErr(seconds, 0); err == nil {
+		t.Error("ToTimeInUnitWithErr() error = nil, want an error for an invalid unit")
+	}
+}
+
+func TestToInt64InUnitWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		unit    time.Duration
+		want    int64
+		wantErr bool
+	}{
+		{"Time in seconds", timeTestValue, time.Second, timeTestValue.Unix(), false},
+		{"Time in microseconds", timeTestValue, time.Microsecond, timeTestValue.UnixMicro(), false},
+		{"Time in hours", timeTestValue, time.Hour, timeTestValue.Unix() / 3600, false},
+		{"Duration in minutes", 90 * time.Minute, time.Minute, 90, false},
+		{"Duration in hours", 90 * time.Minute, time.Hour, 1, false},
+		{"Plain number", "42", time.Second, 42, false},
+		{"Invalid unit", timeTestValue, 7 * time.Millisecond, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToInt64InUnitWithErr(tc.input, tc.unit)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToInt64InUnitWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToInt64InUnitWithErr() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToUint64InUnitWithErr(t *testing.T) {
+	if got, err := ToUint64InUnitWithErr(90*time.Minute, time.Minute); err != nil || got != 90 {
+		t.Errorf("ToUint64InUnitWithErr() = %v, %v, want 90", got, err)
+	}
+	if _, err := ToUint64InUnitWithErr(-time.Minute, time.Second); err == nil {
+		t.Error("ToUint64InUnitWithErr() expected error for a negative duration")
+	}
+	if _, err := ToUint64InUnitWithErr("42", 7*time.Millisecond); err == nil {
+		t.Error("ToUint64InUnitWithErr() expected error for an invalid unit")
+	}
+}
+
+func TestInUnitPanics(t *testing.T) {
+	if got := ToInt64InUnit(90*time.Minute, time.Hour); got != 1 {
+		t.Errorf("ToInt64InUnit() = %v, want 1", got)
+	}
+	if got := ToUint64InUnit(timeTestValue, time.Second); got != uint64(timeTestValue.Unix()) {
+		t.Errorf("ToUint64InUnit() = %v, want %v", got, timeTestValue.Unix())
+	}
+	if got := ToFloat64InUnit(90*time.Minute, time.Hour); got != 1.5 {
+		t.Errorf("ToFloat64InUnit() = %v, want 1.5", got)
+	}
+	if got := ToTimeInUnit(1792195200, time.Second); got.Unix() != 1792195200 {
+		t.Errorf("ToTimeInUnit() = %v", got)
+	}
+
+	for name, convert := range map[string]func(){
+		"ToInt64InUnit":   func() { ToInt64InUnit(1, 0) },
+		"ToUint64InUnit":  func() { ToUint64InUnit(1, 0) },
+		"ToFloat64InUnit": func() { ToFloat64InUnit(1, 0) },
+		"ToTimeInUnit":    func() { ToTimeInUnit(1, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s() expected panic for an invalid unit", name)
+				}
+			}()
+			convert()
+		}()
+	}
+}
+
+func TestToFloat64InUnitWithErr(t *testing.T) {
+	got, err := ToFloat64InUnitWithErr(90*time.Minute, time.Hour)
+	if err != nil {
+		t.Fatalf("ToFloat64InUnitWithErr() error = %v", err)
+	}
+	if got != 1.5 {
+		t.Errorf("ToFloat64InUnitWithErr() = %v, want 1.5", got)
+	}
+}
+
+func TestToTimeInUnitWithErr(t *testing.T) {
+	got, err := ToTimeInUnitWithErr(1792195200, time.Second)
+	if err != nil {
+		t.Fatalf("ToTimeInUnitWithErr() error = %v", err)
+	}
+	if want := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ToTimeInUnitWithErr() = %v, want %v", got, want)
+	}
+}
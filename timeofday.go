@@ -0,0 +1,152 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time, an hour, minute, second and nanosecond without a date or a location.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf returns the wall-clock time of the given time, as seen in the time's own location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// String returns the time of day in the "15:04:05" form, followed by the fraction of a second when it is not zero,
+// e.g. "10:30:00.5".
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// IsZero reports whether the time of day is midnight, the zero value.
+func (t TimeOfDay) IsZero() bool {
+	return t == TimeOfDay{}
+}
+
+// IsValid reports whether every field is within its range, e.g. 24:00:00 is not valid.
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 && t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// On returns the instant of the time of day on the given date in the given location. A nil location means UTC.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return d.At(t, loc)
+}
+
+// Add returns the time of day after the given duration, wrapping around midnight.
+func (t TimeOfDay) Add(d time.Duration) TimeOfDay {
+	return TimeOfDayOf(t.On(Date{Year: 2000, Month: time.January, Day: 1}, time.UTC).Add(d))
+}
+
+// Sub returns the duration from 'other' to the time of day within the same day, negative when 'other' comes later.
+func (t TimeOfDay) Sub(other TimeOfDay) time.Duration {
+	return t.sinceMidnight() - other.sinceMidnight()
+}
+
+// Before reports whether the time of day comes before 'other'.
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.sinceMidnight() < other.sinceMidnight()
+}
+
+// After reports whether the time of day comes after 'other'.
+func (t TimeOfDay) After(other TimeOfDay) bool {
+	return t.sinceMidnight() > other.sinceMidnight()
+}
+
+// MarshalText implements encoding.TextMarshaler using the form returned by String.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler accepting any input supported by ToTimeOfDayWithErr.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	tod, err := ToTimeOfDayWithErr(string(data))
+	if err != nil {
+		return err
+	}
+	*t = tod
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, rendering the time of day as a JSON string.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the time of day unchanged.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// CouldBeTimeOfDay checks if the given value can be converted to a TimeOfDay using ToTimeOfDayWithErr.
+func CouldBeTimeOfDay(a any) bool {
+	_, err := ToTimeOfDayWithErr(a)
+	return err == nil
+}
+
+// ToTimeOfDay converts the given value to a TimeOfDay, panicking if the conversion fails.
+// See ToTimeOfDayWithErr for the accepted inputs.
+func ToTimeOfDay(a any) TimeOfDay {
+	t, err := ToTimeOfDayWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ToTimeOfDayWithErr converts the given value to a TimeOfDay. A TimeOfDay is returned as is, a time.Time is
+// reduced to its wall-clock time in its own location and any other value is parsed with ToTimeWithErr. Strings
+// in the short "15:04" form and with fractional seconds, such as "15:04:05.123", are accepted as well.
+//
+// Example:
+//
+//	t, err := ToTimeOfDayWithErr("09:30")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(t.Add(45 * time.Minute)) // 10:15:00
+func ToTimeOfDayWithErr(a any) (TimeOfDay, error) {
+	switch v := indirectValue(a).(type) {
+	case TimeOfDay:
+		return v, nil
+	case time.Time:
+		return TimeOfDayOf(v), nil
+	case string:
+		for _, layout := range []string{"15:04", "15:04:05.999999999"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return TimeOfDayOf(t), nil
+			}
+		}
+	}
+	t, err := ToTimeWithErr(a)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return TimeOfDayOf(t), nil
+}
+
+func (t TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
@@ -0,0 +1,72 @@
+package converter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToTimeOfDayWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    TimeOfDay
+		wantErr bool
+	}{
+		{"Time only", "15:04:05", TimeOfDay{15, 4, 5, 0}, false},
+		{"Short", "09:30", TimeOfDay{9, 30, 0, 0}, false},
+		{"Fraction", "09:30:00.5", TimeOfDay{9, 30, 0, 500000000}, false},
+		{"Kitchen", "3:04PM", TimeOfDay{15, 4, 0, 0}, false},
+		{"Time", time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC), TimeOfDay{10, 30, 0, 0}, false},
+		{"Invalid", "25:00", TimeOfDay{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToTimeOfDayWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ToTimeOfDayWithErr() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ToTimeOfDayWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeOfDayArithmetic(t *testing.T) {
+	tod := TimeOfDay{Hour: 23, Minute: 30}
+
+	if got := tod.Add(45 * time.Minute); got != (TimeOfDay{Hour: 0, Minute: 15}) {
+		t.Errorf("Add() = %v, want 00:15:00", got)
+	}
+	if got := tod.Sub(TimeOfDay{Hour: 22}); got != 90*time.Minute {
+		t.Errorf("Sub() = %v, want 1h30m", got)
+	}
+	if got := (TimeOfDay{9, 30, 0, 500000000}).String(); got != "09:30:00.5" {
+		t.Errorf("String() = %q, want %q", got, "09:30:00.5")
+	}
+
+	loc := time.FixedZone("BRT", -3*60*60)
+	got := tod.On(Date{2026, time.October, 17}, loc)
+	if want := time.Date(2026, time.October, 17, 23, 30, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("On() = %v, want %v", got, want)
+	}
+}
+
+func TestToDestWithErrTimeOfDay(t *testing.T) {
+	type schedule struct {
+		Opens TimeOfDay `json:"opens"`
+	}
+
+	var dest schedule
+	if err := ToDestWithErr(`{"opens":"08:00"}`, &dest); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if dest.Opens != (TimeOfDay{Hour: 8}) {
+		t.Errorf("ToDestWithErr() = %v, want 08:00:00", dest.Opens)
+	}
+	if got := ToString(dest); got != `{"opens":"08:00:00"}` {
+		t.Errorf("ToString() = %s, want %s", got, `{"opens":"08:00:00"}`)
+	}
+}
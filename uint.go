@@ -67,6 +67,7 @@ func ToUint(a any) uint {
 // Boolean, Interface and Pointer.
 // For Integer, Float and Complex types, if the value is negative, an error is returned.
 // For Interface and Pointer types, if the value is nil, an error is returned.
+// A time.Time is converted to its epoch in DefaultEpochUnit, milliseconds.
 //
 // The function also ensures type safety by returning an error for any unsupported type, or
 // if any error is encountered during the conversion process.
@@ -126,6 +127,15 @@ func ToUintWithErr(a any) (uint, error) {
 			return ToUintWithErr(string(reflectValue.Bytes()))
 		}
 		return 0, fmt.Errorf("error convert to uint, unsupported type %s", reflectValue.Kind().String())
+	case reflect.Struct:
+		if t, ok := timeIfPresent(reflectValue); ok {
+			i := epochFromTime(t, DefaultEpochUnit)
+			if i < 0 {
+				return 0, fmt.Errorf("error: trying to convert a negative int to uint: got %v", i)
+			}
+			return uint(i), nil
+		}
+		return 0, fmt.Errorf("error convert to uint, unsupported type %s", reflectValue.Kind().String())
 	case reflect.Interface, reflect.Pointer:
 		if reflectValue.IsNil() {
 			return 0, errors.New("error convert to uint, it is null")
@@ -0,0 +1,194 @@
+package converter
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ulidAlphabet is the Crockford base32 alphabet, which leaves out I, L, O and U.
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID is a universally unique lexicographically sortable identifier: a 48-bit Unix timestamp in milliseconds
+// followed by 80 random bits, written as 26 Crockford base32 characters.
+//
+// The zero value is reported by IsZero. Raw bytes are available by slicing, e.g. id[:].
+type ULID [16]byte
+
+// NewULID returns a ULID holding the current time, according to the clock configured with SetClock, and random
+// bits. It panics if the system random source fails.
+func NewULID() ULID {
+	var id ULID
+	readRandom(id[6:])
+	putUint48(id[:6], uint64(Clock().UnixMilli()))
+	return id
+}
+
+// String returns the ULID as 26 upper case Crockford base32 characters, e.g. "01ARZ3NDEKTSV4RRFFQ69G5FAV".
+func (id ULID) String() string {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = ulidAlphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// IsZero reports whether the ULID has all bits set to zero.
+func (id ULID) IsZero() bool {
+	return id == ULID{}
+}
+
+// Time returns the creation time embedded in the ULID, with millisecond precision, in UTC.
+func (id ULID) Time() time.Time {
+	return time.UnixMilli(int64(uint48(id[:6]))).UTC()
+}
+
+// MarshalText implements encoding.TextMarshaler, rendering the ULID in its base32 form.
+func (id ULID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler accepting the forms supported by ToULIDWithErr.
+func (id *ULID) UnmarshalText(data []byte) error {
+	parsed, err := parseULID(string(data))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, rendering the ULID as a JSON string in its base32 form.
+func (id ULID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the ULID unchanged.
+func (id *ULID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, accepting text columns in the forms supported by ToULIDWithErr and 16-byte binary
+// columns. A NULL column sets the zero ULID.
+func (id *ULID) Scan(src any) error {
+	if src == nil {
+		*id = ULID{}
+		return nil
+	}
+	parsed, err := ToULIDWithErr(src)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the ULID in its base32 form.
+func (id ULID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// CouldBeULID checks if the given value can be converted to a ULID using ToULIDWithErr.
+//
+// Example:
+//
+//	fmt.Println(CouldBeULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")) // true
+//	fmt.Println(CouldBeULID("01ARZ3NDEK"))                 // false
+func CouldBeULID(a any) bool {
+	_, err := ToULIDWithErr(a)
+	return err == nil
+}
+
+// ToULID converts the given value to a ULID, panicking if the conversion fails. See ToULIDWithErr.
+func ToULID(a any) ULID {
+	id, err := ToULIDWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ToULIDWithErr converts the given value to a ULID.
+//
+// A ULID is returned as is, while a UUID, a [16]byte and a 16-byte slice are taken as its raw bytes. Any other value
+// is converted to a string using ToStringWithErr and decoded from 26 Crockford base32 characters. Decoding is case
+// insensitive and, as Crockford base32 allows, I and L are read as 1 and O as 0.
+//
+// Example:
+//
+//	id, err := ToULIDWithErr("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(id.Time()) // 2016-07-30 23:54:10.259 +0000 UTC
+func ToULIDWithErr(a any) (ULID, error) {
+	switch v := indirectValue(a).(type) {
+	case ULID:
+		return v, nil
+	case UUID:
+		return ULID(v), nil
+	case [16]byte:
+		return v, nil
+	case []byte:
+		if len(v) == 16 {
+			return ULID(v), nil
+		}
+	}
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return ULID{}, err
+	}
+	return parseULID(s)
+}
+
+func parseULID(s string) (ULID, error) {
+	text := strings.TrimSpace(s)
+	if len(text) != 26 {
+		return ULID{}, fmt.Errorf("error convert to ULID, expected 26 characters, got %d", len(text))
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(text); i++ {
+		value := ulidCharValue(text[i])
+		if value < 0 {
+			return ULID{}, fmt.Errorf("error convert to ULID, invalid character %q at position %d", text[i], i)
+		} else if i == 0 && value > 7 {
+			return ULID{}, fmt.Errorf("error convert to ULID, value %q overflows 128 bits", s)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(value)
+	}
+
+	var id ULID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
+
+func ulidCharValue(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch c {
+	case 'I', 'L':
+		return 1
+	case 'O':
+		return 0
+	}
+	return strings.IndexByte(ulidAlphabet, c)
+}
@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToULIDWithErr(t *testing.T) {
+	want := ToULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+
+	testCases := []struct {
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Canonical", "01ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"Lower case", "01arz3ndektsv4rrffq69g5fav", false},
+		{"Crockford aliases", "O1ARZ3NDEKTSV4RRFFQ69G5FAV", false},
+		{"ULID", want, false},
+		{"Raw bytes", want[:], false},
+		{"UUID", UUID(want), false},
+		{"Overflow", "81ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"Invalid character", "01ARZ3NDEKTSV4RRFFQ69G5FAU", true},
+		{"Short", "01ARZ3NDEK", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToULIDWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToULIDWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != want {
+				t.Errorf("ToULIDWithErr() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestULID(t *testing.T) {
+	id := ToULID("01arz3ndektsv4rrffq69g5fav")
+	if id.String() != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("String() = %v", id.String())
+	}
+	if want := time.UnixMilli(1469922850259).UTC(); !id.Time().Equal(want) {
+		t.Errorf("Time() = %v, want %v", id.Time(), want)
+	}
+	if ToString(id) != id.String() {
+		t.Errorf("ToString() = %v", ToString(id))
+	}
+	if maxID := ToULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ"); maxID.String() != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("String() max = %v", maxID.String())
+	}
+
+	SetClock(func() time.Time { return time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC) })
+	defer SetClock(nil)
+	generated := NewULID()
+	if !generated.Time().Equal(Clock()) {
+		t.Errorf("NewULID().Time() = %v, want %v", generated.Time(), Clock())
+	}
+	if back := ToULID(generated.String()); back != generated {
+		t.Errorf("ToULID() round trip = %v, want %v", back, generated)
+	}
+}
+
+func TestULIDMarshaling(t *testing.T) {
+	var dest struct {
+		ID ULID `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`), &dest); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	bs, err := json.Marshal(dest)
+	if err != nil || string(bs) != `{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}` {
+		t.Errorf("json.Marshal() = %s, %v", bs, err)
+	}
+
+	var id ULID
+	if err = ToDestWithErr("01ARZ3NDEKTSV4RRFFQ69G5FAV", &id); err != nil || id != dest.ID {
+		t.Errorf("ToDestWithErr() = %v, %v", id, err)
+	}
+	if err = id.Scan(nil); err != nil || !id.IsZero() {
+		t.Errorf("Scan() nil = %v, %v", id, err)
+	}
+	if err = id.Scan("01ARZ3NDEKTSV4RRFFQ69G5FAV"); err != nil || id != dest.ID {
+		t.Errorf("Scan() = %v, %v", id, err)
+	}
+	if value, err := id.Value(); err != nil || value != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("Value() = %v, %v", value, err)
+	}
+}
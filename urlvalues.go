@@ -0,0 +1,288 @@
+package converter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// urlTags are the struct tags that name fields in query strings, in order of precedence.
+var urlTags = []string{"url", "json"}
+
+var urlValuesType = reflect.TypeOf(url.Values{})
+
+// ToURLValues converts the given struct or map to url.Values, panicking if the conversion fails.
+// See ToURLValuesWithErr.
+func ToURLValues(a any) url.Values {
+	values, err := ToURLValuesWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return values
+}
+
+// ToURLValuesWithErr converts the given struct or map to url.Values, the form used by query strings and
+// application/x-www-form-urlencoded bodies.
+//
+// Struct fields are named by their `url` tag, then their `json` tag, then their name, and fields tagged with "-"
+// are skipped. With the omitempty option, e.g. `url:"page,omitempty"`, zero values are skipped. Nil pointers are
+// always skipped. Embedded structs have their fields promoted.
+//
+// Values are flattened as follows:
+//   - Scalars are rendered with ToStringWithErr, so time.Time uses DefaultTimeLayout, or with MarshalText for
+//     types that implement encoding.TextMarshaler but not fmt.Stringer.
+//   - Slices and arrays of scalars become repeated keys, e.g. "tag=a&tag=b".
+//   - Nested structs and maps use bracketed keys, e.g. "filter[status]=paid".
+//   - Slices and arrays of structs or maps use indexed keys, e.g. "items[0][sku]=A1".
+//
+// Parameters:
+//   - a: The struct or map to be converted, or a pointer to one.
+//
+// Returns:
+//   - url.Values: The flattened values.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	type Filter struct {
+//		Status string `url:"status"`
+//	}
+//	type Query struct {
+//		Page   int      `url:"page,omitempty"`
+//		Tags   []string `url:"tag"`
+//		Filter Filter   `url:"filter"`
+//	}
+//	values, _ := ToURLValuesWithErr(Query{Tags: []string{"a", "b"}, Filter: Filter{Status: "paid"}})
+//	fmt.Println(values.Encode()) // filter%5Bstatus%5D=paid&tag=a&tag=b
+func ToURLValuesWithErr(a any) (url.Values, error) {
+	if a == nil {
+		return nil, errors.New("error convert to url.Values, it is null")
+	}
+
+	reflectValue := reflect.ValueOf(indirectValue(a))
+	if reflectValue.Kind() != reflect.Struct && reflectValue.Kind() != reflect.Map || isWeakLeaf(reflectValue.Type()) {
+		return nil, fmt.Errorf("error convert to url.Values, unsupported type %s", reflectValue.Kind().String())
+	}
+
+	values := url.Values{}
+	if err := flattenURLValues(values, "", reflectValue); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ToQueryString converts the given struct or map to a query string, panicking if the conversion fails.
+// See ToQueryStringWithErr.
+func ToQueryString(a any) string {
+	s, err := ToQueryStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToQueryStringWithErr converts the given struct or map to url.Values using ToURLValuesWithErr and encodes them as a
+// query string, sorted by key and without the leading "?". Prefix it with "?" to decode it with ToDestWithErr.
+//
+// Example:
+//
+//	s, _ := ToQueryStringWithErr(map[string]any{"page": 2, "q": "go lang"})
+//	fmt.Println(s) // page=2&q=go+lang
+func ToQueryStringWithErr(a any) (string, error) {
+	values, err := ToURLValuesWithErr(a)
+	if err != nil {
+		return "", err
+	}
+	return values.Encode(), nil
+}
+
+func flattenURLValues(values url.Values, key string, value reflect.Value) error {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if isWeakScalar(value.Type()) {
+		s, err := weakString(value)
+		if err != nil {
+			return err
+		}
+		values.Add(key, s)
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for _, field := range weakFields(value.Type(), urlTags) {
+			fieldValue := value.FieldByIndex(field.index)
+			if field.omitEmpty && fieldValue.IsZero() {
+				continue
+			}
+			if err := flattenURLValues(values, urlValuesKey(key, field.name), fieldValue); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys, byKey, err := sortedMapKeys(value)
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := flattenURLValues(values, urlValuesKey(key, k), byKey[k]); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			s, err := ToStringWithErr(value.Interface())
+			if err != nil {
+				return err
+			}
+			values.Add(key, s)
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			elem := value.Index(i)
+			for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Interface {
+				if elem.IsNil() {
+					break
+				}
+				elem = elem.Elem()
+			}
+			elemKey := key
+			if elem.IsValid() && !isWeakScalar(elem.Type()) {
+				elemKey = key + "[" + strconv.Itoa(i) + "]"
+			}
+			if err := flattenURLValues(values, elemKey, elem); err != nil {
+				return err
+			}
+		}
+	default:
+		s, err := ToStringWithErr(value.Interface())
+		if err != nil {
+			return err
+		}
+		values.Add(key, s)
+	}
+	return nil
+}
+
+func urlValuesKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+// urlValuesIfPresent returns the values of a url.Values source, given as value or pointer.
+func urlValuesIfPresent(a any) (url.Values, bool) {
+	switch v := a.(type) {
+	case url.Values:
+		return v, true
+	case *url.Values:
+		return *v, v != nil
+	case map[string][]string:
+		return v, true
+	}
+	return nil, false
+}
+
+// parseQueryStringIfPresent parses the given bytes as a query string when they start with "?". Other text is never
+// taken as a query string, since values such as base64 padded with "=" would otherwise be silently misread.
+func parseQueryStringIfPresent(bs []byte) (url.Values, bool, error) {
+	bs = bytes.TrimSpace(bs)
+	if !bytes.HasPrefix(bs, []byte("?")) {
+		return nil, false, nil
+	}
+	values, err := url.ParseQuery(string(bs[1:]))
+	if err != nil {
+		return nil, true, fmt.Errorf("error convert from query string, %w", err)
+	}
+	return values, true, nil
+}
+
+// decodeURLValues fills dest with the given values, nesting bracketed keys, e.g. "filter[status]", and collecting
+// repeated keys, e.g. "tag=a&tag=b" or "tag[]=a&tag[]=b", into slices.
+func decodeURLValues(values url.Values, reflectDest reflect.Value) error {
+	if elem := reflectDest.Elem(); elem.Type().ConvertibleTo(urlValuesType) && elem.Kind() == reflect.Map {
+		copied := make(url.Values, len(values))
+		for key, vs := range values {
+			copied[key] = append([]string(nil), vs...)
+		}
+		elem.Set(reflect.ValueOf(copied).Convert(elem.Type()))
+		return nil
+	}
+
+	tree, err := urlValuesTree(values)
+	if err != nil {
+		return err
+	}
+	return weakDecoder{tags: urlTags}.decode(tree, reflectDest.Elem())
+}
+
+func urlValuesTree(values url.Values) (map[string]any, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// "tag" and "tag[]" name the same list, so their values are merged, the ones of "tag" first.
+	merged := make(map[string][]string, len(keys))
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		name := strings.TrimSuffix(key, "[]")
+		if _, ok := merged[name]; !ok {
+			names = append(names, name)
+		}
+		merged[name] = append(merged[name], values[key]...)
+	}
+
+	tree := map[string]any{}
+	for _, key := range names {
+		path, err := splitURLValuesKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any = merged[key][0]
+		if len(merged[key]) > 1 {
+			items := make([]any, len(merged[key]))
+			for i, v := range merged[key] {
+				items[i] = v
+			}
+			value = items
+		}
+
+		if err = weakTreeSet(tree, path, value); err != nil {
+			return nil, fmt.Errorf("error convert from query string, key %q: %w", key, err)
+		}
+	}
+	return tree, nil
+}
+
+// splitURLValuesKey splits a bracketed key, e.g. "items[0][sku]", into its path, dropping a trailing "[]".
+func splitURLValuesKey(key string) ([]string, error) {
+	key = strings.TrimSuffix(key, "[]")
+
+	open := strings.IndexByte(key, '[')
+	if open < 0 {
+		return []string{key}, nil
+	}
+	path := []string{key[:open]}
+	for rest := key[open:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 2 {
+			return nil, fmt.Errorf("error convert from query string, invalid key %q", key)
+		}
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return path, nil
+}
@@ -0,0 +1,185 @@
+package converter
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type urlTestFilter struct {
+	Status string `url:"status"`
+	MinAge int    `url:"min_age,omitempty"`
+}
+
+type urlTestItem struct {
+	SKU string `url:"sku"`
+	Qty int    `url:"qty"`
+}
+
+type urlTestPaging struct {
+	Page int `url:"page,omitempty"`
+}
+
+type urlTestQuery struct {
+	urlTestPaging
+	Search  string        `json:"q"`
+	Tags    []string      `url:"tag"`
+	Filter  urlTestFilter `url:"filter"`
+	Items   []urlTestItem `url:"items,omitempty"`
+	Since   *time.Time    `url:"since"`
+	ID      UUID          `url:"id,omitempty"`
+	Ignored string        `url:"-"`
+}
+
+func TestToURLValuesWithErr(t *testing.T) {
+	since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		input   any
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "Struct",
+			input: urlTestQuery{
+				urlTestPaging: urlTestPaging{Page: 2},
+				Search:        "go lang",
+				Tags:          []string{"a", "b"},
+				Filter:        urlTestFilter{Status: "paid"},
+				Items:         []urlTestItem{{SKU: "A1", Qty: 1}},
+				Since:         &since,
+				Ignored:       "x",
+			},
+			want: url.Values{
+				"page":           {"2"},
+				"q":              {"go lang"},
+				"tag":            {"a", "b"},
+				"filter[status]": {"paid"},
+				"items[0][sku]":  {"A1"},
+				"items[0][qty]":  {"1"},
+				"since":          {"2024-05-01T10:00:00Z"},
+			},
+		},
+		{
+			name:  "Struct zero values",
+			input: &urlTestQuery{},
+			want:  url.Values{"q": {""}, "filter[status]": {""}},
+		},
+		{
+			name:  "Map",
+			input: map[string]any{"page": 1, "ids": []int{3, 4}, "filter": map[string]string{"status": "open"}},
+			want:  url.Values{"page": {"1"}, "ids": {"3", "4"}, "filter[status]": {"open"}},
+		},
+		{name: "Unsupported", input: []int{1}, wantErr: true},
+		{name: "Time", input: since, wantErr: true},
+		{name: "Nil", input: nil, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToURLValuesWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToURLValuesWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToURLValuesWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToQueryStringWithErr(t *testing.T) {
+	got, err := ToQueryStringWithErr(map[string]any{"page": 2, "q": "go lang", "filter": map[string]any{"a": true}})
+	if err != nil {
+		t.Fatalf("ToQueryStringWithErr() error = %v", err)
+	}
+	if want := "filter%5Ba%5D=true&page=2&q=go+lang"; got != want {
+		t.Errorf("ToQueryStringWithErr() = %v, want %v", got, want)
+	}
+}
+
+func TestToDestWithErrQueryString(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    urlTestQuery
+		wantErr bool
+	}{
+		{
+			name:  "Query string",
+			input: "?page=3&q=go+lang&tag=a&tag=b&filter[status]=paid&filter[min_age]=18",
+			want: urlTestQuery{
+				urlTestPaging: urlTestPaging{Page: 3},
+				Search:        "go lang",
+				Tags:          []string{"a", "b"},
+				Filter:        urlTestFilter{Status: "paid", MinAge: 18},
+			},
+		},
+		{
+			name:  "Bracketed slices",
+			input: "?tag[]=x&items[1][sku]=B2&items[0][sku]=A1&items[0][qty]=2&ID=0190a7b2-1c3d-7e4f-8a5b-6c7d8e9f0a1b",
+			want: urlTestQuery{
+				Tags:  []string{"x"},
+				Items: []urlTestItem{{SKU: "A1", Qty: 2}, {SKU: "B2"}},
+				ID:    ToUUID("0190a7b2-1c3d-7e4f-8a5b-6c7d8e9f0a1b"),
+			},
+		},
+		{
+			name:  "url.Values",
+			input: url.Values{"page": {"1"}, "since": {"2024-05-01"}, "Ignored": {"x"}, "filter[status]": {""}},
+			want: urlTestQuery{
+				urlTestPaging: urlTestPaging{Page: 1},
+				Since:         ToPointer(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:  "Plain and bracketed slice keys",
+			input: url.Values{"tag[]": {"b", "c"}, "tag": {"a"}},
+			want:  urlTestQuery{Tags: []string{"a", "b", "c"}},
+		},
+		{name: "Plain and bracketed single values", input: "?tag[]=b&tag=a", want: urlTestQuery{Tags: []string{"a", "b"}}},
+		{name: "Empty numbers", input: "?page=&filter[min_age]=", want: urlTestQuery{}},
+		{name: "Invalid number", input: "?page=abc", wantErr: true},
+		{name: "Conflicting keys", input: "?filter=1&filter[status]=2", wantErr: true},
+		{name: "Invalid key", input: "?filter[status=2", wantErr: true},
+		{name: "Without leading question mark", input: "page=3", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got urlTestQuery
+			err := ToDestWithErr(tc.input, &got)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToDestWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToDestWithErr() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDestWithErrQueryStringMaps(t *testing.T) {
+	var tree map[string]any
+	if err := ToDestWithErr("?a=1&a=2&b[c]=3", &tree); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if want := map[string]any{"a": []any{"1", "2"}, "b": map[string]any{"c": "3"}}; !reflect.DeepEqual(tree, want) {
+		t.Errorf("ToDestWithErr() = %v, want %v", tree, want)
+	}
+
+	var values url.Values
+	if err := ToDestWithErr("?b[c]=3&a=1", &values); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if want := (url.Values{"a": {"1"}, "b[c]": {"3"}}); !reflect.DeepEqual(values, want) {
+		t.Errorf("ToDestWithErr() = %v, want %v", values, want)
+	}
+
+	var counts map[string]int
+	if err := ToDestWithErr(`{"a":1}`, &counts); err != nil || counts["a"] != 1 {
+		t.Errorf("ToDestWithErr() = %v, %v, want JSON to be decoded", counts, err)
+	}
+}
@@ -0,0 +1,242 @@
+package converter
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// uuidGregorianOffset is the number of 100-nanosecond intervals between the start of the Gregorian calendar,
+// 1582-10-15, and the Unix epoch, used by the version 1 and 6 timestamps.
+const uuidGregorianOffset = 122192928000000000
+
+// UUID is a universally unique identifier as defined in RFC 9562.
+//
+// The zero value is the nil UUID, reported by IsZero. Raw bytes are available by slicing, e.g. u[:].
+type UUID [16]byte
+
+// NewUUIDv4 returns a random version 4 UUID. It panics if the system random source fails.
+func NewUUIDv4() UUID {
+	var u UUID
+	readRandom(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// NewUUIDv7 returns a version 7 UUID, whose first 48 bits hold the current Unix time in milliseconds, according
+// to the clock configured with SetClock, followed by random bits. Such UUIDs sort by creation time.
+// It panics if the system random source fails.
+func NewUUIDv7() UUID {
+	var u UUID
+	readRandom(u[6:])
+	putUint48(u[:6], uint64(Clock().UnixMilli()))
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// String returns the UUID in its canonical lower case form, e.g. "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// URN returns the UUID as a URN, e.g. "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (u UUID) URN() string {
+	return "urn:uuid:" + u.String()
+}
+
+// IsZero reports whether the UUID is the nil UUID, with all bits set to zero.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// Version returns the version of the UUID, e.g. 4 for a random UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns the creation time embedded in a version 1, 6 or 7 UUID. The second result is false for the other
+// versions, which carry no timestamp.
+func (u UUID) Time() (time.Time, bool) {
+	switch u.Version() {
+	case 1:
+		ticks := uint64(binary.BigEndian.Uint16(u[6:8])&0x0fff)<<48 | uint64(binary.BigEndian.Uint16(u[4:6]))<<32 |
+			uint64(binary.BigEndian.Uint32(u[0:4]))
+		return uuidGregorianTime(ticks), true
+	case 6:
+		ticks := uint64(binary.BigEndian.Uint32(u[0:4]))<<28 | uint64(binary.BigEndian.Uint16(u[4:6]))<<12 |
+			uint64(binary.BigEndian.Uint16(u[6:8])&0x0fff)
+		return uuidGregorianTime(ticks), true
+	case 7:
+		return time.UnixMilli(int64(uint48(u[:6]))).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// MarshalText implements encoding.TextMarshaler, rendering the UUID in its canonical form.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler accepting any form supported by ToUUIDWithErr.
+func (u *UUID) UnmarshalText(data []byte) error {
+	parsed, err := parseUUID(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, rendering the UUID as a JSON string in its canonical form.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the UUID unchanged.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner, accepting text columns in any form supported by ToUUIDWithErr and 16-byte binary
+// columns. A NULL column sets the nil UUID.
+func (u *UUID) Scan(src any) error {
+	if src == nil {
+		*u = UUID{}
+		return nil
+	}
+	parsed, err := ToUUIDWithErr(src)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, storing the UUID in its canonical text form.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// CouldBeUUID checks if the given value can be converted to a UUID using ToUUIDWithErr.
+//
+// Example:
+//
+//	fmt.Println(CouldBeUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")) // true
+//	fmt.Println(CouldBeUUID("f81d4fae"))                             // false
+func CouldBeUUID(a any) bool {
+	_, err := ToUUIDWithErr(a)
+	return err == nil
+}
+
+// ToUUID converts the given value to a UUID, panicking if the conversion fails. See ToUUIDWithErr.
+func ToUUID(a any) UUID {
+	u, err := ToUUIDWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// ToUUIDWithErr converts the given value to a UUID.
+//
+// A UUID is returned as is, while a ULID, a [16]byte and a 16-byte slice are taken as its raw bytes. Any other value
+// is converted to a string using ToStringWithErr and parsed, case insensitively, in one of these forms:
+//   - "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+//   - "f81d4fae7dec11d0a76500a0c91e6bf6"
+//   - "{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}"
+//   - "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+//
+// Example:
+//
+//	u, err := ToUUIDWithErr("{F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6}")
+//	if err != nil {
+//		fmt.Println(err)
+//	}
+//	fmt.Println(u, u.Version()) // f81d4fae-7dec-11d0-a765-00a0c91e6bf6 1
+func ToUUIDWithErr(a any) (UUID, error) {
+	switch v := indirectValue(a).(type) {
+	case UUID:
+		return v, nil
+	case ULID:
+		return UUID(v), nil
+	case [16]byte:
+		return v, nil
+	case []byte:
+		if len(v) == 16 {
+			return UUID(v), nil
+		}
+	}
+	s, err := ToStringWithErr(a)
+	if err != nil {
+		return UUID{}, err
+	}
+	return parseUUID(s)
+}
+
+func parseUUID(s string) (UUID, error) {
+	text := strings.TrimSpace(s)
+	if len(text) > 9 && strings.EqualFold(text[:9], "urn:uuid:") {
+		text = text[9:]
+	} else if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+		text = text[1 : len(text)-1]
+	}
+	if len(text) == 36 {
+		if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+			return UUID{}, fmt.Errorf("error convert to UUID, invalid format %q", s)
+		}
+		text = text[0:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	}
+
+	var u UUID
+	if len(text) != 32 {
+		return UUID{}, fmt.Errorf("error convert to UUID, invalid format %q", s)
+	} else if _, err := hex.Decode(u[:], []byte(text)); err != nil {
+		return UUID{}, fmt.Errorf("error convert to UUID, invalid format %q", s)
+	}
+	return u, nil
+}
+
+func uuidGregorianTime(ticks uint64) time.Time {
+	unixTicks := int64(ticks) - uuidGregorianOffset
+	return time.Unix(unixTicks/1e7, unixTicks%1e7*100).UTC()
+}
+
+func readRandom(bs []byte) {
+	if _, err := rand.Read(bs); err != nil {
+		panic(err)
+	}
+}
+
+func uint48(bs []byte) uint64 {
+	return uint64(bs[0])<<40 | uint64(bs[1])<<32 | uint64(bs[2])<<24 | uint64(bs[3])<<16 | uint64(bs[4])<<8 |
+		uint64(bs[5])
+}
+
+func putUint48(bs []byte, v uint64) {
+	bs[0], bs[1], bs[2], bs[3], bs[4], bs[5] = byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v)
+}
@@ -0,0 +1,159 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToUUIDWithErr(t *testing.T) {
+	want := UUID{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+
+	testCases := []struct {
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{"Canonical", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", false},
+		{"Upper case", "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6", false},
+		{"No dashes", "f81d4fae7dec11d0a76500a0c91e6bf6", false},
+		{"Braces", "{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}", false},
+		{"URN", "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", false},
+		{"UUID", want, false},
+		{"Pointer", &want, false},
+		{"Array", [16]byte(want), false},
+		{"Raw bytes", want[:], false},
+		{"ULID", ULID(want), false},
+		{"Misplaced dashes", "f81d4fae7-dec-11d0-a765-00a0c91e6bf6", true},
+		{"Invalid hex", "g81d4fae-7dec-11d0-a765-00a0c91e6bf6", true},
+		{"Short", "f81d4fae", true},
+		{"Nil", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToUUIDWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToUUIDWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && got != want {
+				t.Errorf("ToUUIDWithErr() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUUIDFormatting(t *testing.T) {
+	u := ToUUID("F81D4FAE7DEC11D0A76500A0C91E6BF6")
+	if u.String() != "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" {
+		t.Errorf("String() = %v", u.String())
+	}
+	if u.URN() != "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6" {
+		t.Errorf("URN() = %v", u.URN())
+	}
+	if ToString(u) != u.String() || string(ToBytes(u)) != u.String() {
+		t.Errorf("ToString() = %v", ToString(u))
+	}
+	if !(UUID{}).IsZero() || u.IsZero() {
+		t.Error("IsZero() mismatch")
+	}
+}
+
+func TestUUIDTime(t *testing.T) {
+	want := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+
+	testCases := []struct {
+		input   string
+		version int
+		ok      bool
+	}{
+		{"C232AB00-9414-11EC-B3C8-9F6BDECED846", 1, true},
+		{"1EC9414C-232A-6B00-B3C8-9F6BDECED846", 6, true},
+		{"017F22E2-79B0-7CC3-98C4-DC0C0C07398F", 7, true},
+		{"919108f7-52d1-4320-9bac-f847db4148a8", 4, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			u := ToUUID(tc.input)
+			if u.Version() != tc.version {
+				t.Errorf("Version() = %v, want %v", u.Version(), tc.version)
+			}
+			got, ok := u.Time()
+			if ok != tc.ok {
+				t.Fatalf("Time() ok = %v, want %v", ok, tc.ok)
+			}
+			if ok && !got.Equal(want) {
+				t.Errorf("Time() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	SetClock(func() time.Time { return time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC) })
+	defer SetClock(nil)
+
+	v4 := NewUUIDv4()
+	if v4.Version() != 4 || v4[8]&0xc0 != 0x80 {
+		t.Errorf("NewUUIDv4() = %v", v4)
+	}
+	if v4 == NewUUIDv4() {
+		t.Error("NewUUIDv4() returned the same UUID twice")
+	}
+
+	v7 := NewUUIDv7()
+	if v7.Version() != 7 || v7[8]&0xc0 != 0x80 {
+		t.Errorf("NewUUIDv7() = %v", v7)
+	}
+	if got, _ := v7.Time(); !got.Equal(Clock()) {
+		t.Errorf("NewUUIDv7().Time() = %v, want %v", got, Clock())
+	}
+}
+
+func TestUUIDMarshaling(t *testing.T) {
+	type entity struct {
+		ID       UUID  `json:"id"`
+		ParentID *UUID `json:"parentId"`
+	}
+
+	var e entity
+	err := json.Unmarshal([]byte(`{"id":"{F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6}","parentId":null}`), &e)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	bs, err := json.Marshal(e)
+	if err != nil || string(bs) != `{"id":"f81d4fae-7dec-11d0-a765-00a0c91e6bf6","parentId":null}` {
+		t.Errorf("json.Marshal() = %s, %v", bs, err)
+	}
+
+	var dest UUID
+	if err = ToDestWithErr("urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", &dest); err != nil || dest != e.ID {
+		t.Errorf("ToDestWithErr() = %v, %v", dest, err)
+	}
+	if err = ToDestWithErr("invalid", &dest); err == nil {
+		t.Error("ToDestWithErr() expected error")
+	}
+}
+
+func TestUUIDSQL(t *testing.T) {
+	var u UUID
+	if err := u.Scan([]byte("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	value, err := u.Value()
+	if err != nil || value != "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" {
+		t.Errorf("Value() = %v, %v", value, err)
+	}
+
+	var raw UUID
+	if err = raw.Scan(u[:]); err != nil || raw != u {
+		t.Errorf("Scan() raw = %v, %v", raw, err)
+	}
+	if err = raw.Scan(nil); err != nil || !raw.IsZero() {
+		t.Errorf("Scan() nil = %v, %v", raw, err)
+	}
+	if err = raw.Scan(10); err == nil {
+		t.Error("Scan() expected error")
+	}
+}
@@ -0,0 +1,509 @@
+package converter
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// weakField is a struct field as seen by the weak typed decoder and the flatteners built on it.
+type weakField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+}
+
+// weakDecoder assigns generic trees, made of map[string]any, []any and scalars such as strings, into typed values
+// with weak typing, so that the string "10" fills an int and a single value fills a slice. Scalars are converted
+// with ToDestWithErr, so every input it accepts can be used, and time.Duration also accepts the format of
+// time.ParseDuration, e.g. "90s".
+//
+// It is shared by the decoders of loosely typed formats, such as query strings and configuration files.
+type weakDecoder struct {
+	// tags name the struct fields, in order of precedence. Fields without them are named by the field name, and
+	// fields are matched case insensitively when there is no exact match.
+	tags []string
+	// separator splits a string into the elements of a slice, e.g. "," for "a,b". When empty, a string fills a
+	// slice as a single element.
+	separator string
+	// prefixSeparator lets nested structs and maps be filled from flat keys prefixed by the name of their field, e.g.
+	// "DB_HOST" for the field "DB" when it is "_". When empty, nested values must be objects.
+	prefixSeparator string
+}
+
+func (d weakDecoder) decode(src any, dest reflect.Value) error {
+	if src == nil {
+		return nil
+	}
+
+	switch dest.Kind() {
+	case reflect.Pointer:
+		if dest.IsNil() {
+			dest.Set(reflect.New(dest.Type().Elem()))
+		}
+		return d.decode(src, dest.Elem())
+	case reflect.Interface:
+		if dest.NumMethod() == 0 {
+			dest.Set(reflect.ValueOf(src))
+			return nil
+		}
+	}
+	if isWeakLeaf(dest.Type()) {
+		return d.decodeScalar(src, dest)
+	}
+
+	switch dest.Kind() {
+	case reflect.Struct:
+		fields, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("error convert to %s, expected an object, got %T", dest.Type().String(), src)
+		}
+		for _, field := range weakFields(dest.Type(), d.tags) {
+			value, ok := fields[field.name]
+			if !ok {
+				for key, v := range fields {
+					if strings.EqualFold(key, field.name) {
+						value, ok = v, true
+						break
+					}
+				}
+			}
+			fieldValue := dest.FieldByIndex(field.index)
+			if !ok && d.prefixSeparator != "" && isWeakObject(fieldValue.Type()) {
+				value, ok = d.prefixed(fields, field.name+d.prefixSeparator)
+			}
+			if !ok {
+				continue
+			}
+			if err := d.decode(value, fieldValue); err != nil {
+				return fmt.Errorf("%s: %w", field.name, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		fields, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("error convert to %s, expected an object, got %T", dest.Type().String(), src)
+		}
+		if dest.IsNil() {
+			dest.Set(reflect.MakeMapWithSize(dest.Type(), len(fields)))
+		}
+		for key, value := range fields {
+			mapKey := reflect.New(dest.Type().Key())
+			if err := ToDestWithErr(key, mapKey.Interface()); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			elem := reflect.New(dest.Type().Elem()).Elem()
+			if err := d.decode(value, elem); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			dest.SetMapIndex(mapKey.Elem(), elem)
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if dest.Type().Elem().Kind() == reflect.Uint8 {
+			if dest.Kind() == reflect.Slice {
+				bs, err := ToBytesWithErr(src)
+				if err != nil {
+					return err
+				}
+				dest.SetBytes(bs)
+				return nil
+			}
+			break
+		}
+		items, err := d.items(src)
+		if err != nil {
+			return err
+		}
+		if dest.Kind() == reflect.Slice {
+			dest.Set(reflect.MakeSlice(dest.Type(), len(items), len(items)))
+		} else if len(items) > dest.Len() {
+			return fmt.Errorf("error convert to %s, got %d elements", dest.Type().String(), len(items))
+		}
+		for i, item := range items {
+			if err = d.decode(item, dest.Index(i)); err != nil {
+				return fmt.Errorf("%d: %w", i, err)
+			}
+		}
+		return nil
+	}
+	return d.decodeScalar(src, dest)
+}
+
+// prefixed returns the fields whose keys start with the given prefix, case insensitively, with the prefix removed.
+func (d weakDecoder) prefixed(fields map[string]any, prefix string) (map[string]any, bool) {
+	nested := map[string]any{}
+	for key, value := range fields {
+		if len(key) > len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+			nested[key[len(prefix):]] = value
+		}
+	}
+	return nested, len(nested) > 0
+}
+
+func (d weakDecoder) decodeScalar(src any, dest reflect.Value) error {
+	if items, ok := src.([]any); ok {
+		if len(items) == 0 {
+			return nil
+		}
+		src = items[0]
+	}
+	if fields, ok := src.(map[string]any); ok {
+		if src, ok = fields[weakValueKey]; !ok {
+			return fmt.Errorf("error convert to %s, unexpected object", dest.Type().String())
+		}
+	}
+	if s, ok := src.(string); ok && s == "" && dest.Kind() != reflect.String {
+		return nil
+	}
+
+	if s, ok := src.(string); ok && dest.Type() == reflect.TypeOf(time.Duration(0)) {
+		if duration, err := time.ParseDuration(s); err == nil {
+			dest.SetInt(int64(duration))
+			return nil
+		}
+	}
+
+	if ok, err := resolveDestImplementsIfPresent(src, dest.Addr()); ok {
+		return err
+	} else if dest.Addr().Type().Implements(textUnmarshalerType) {
+		s, err := ToStringWithErr(src)
+		if err != nil {
+			return err
+		}
+		return dest.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	return ToDestWithErr(src, dest.Addr().Interface())
+}
+
+// items returns the elements of a generic tree node to be decoded into a slice. Objects whose keys are indexes, as
+// produced by "items[0]=a&items[1]=b", are ordered by index, strings are split by the separator, when there is one,
+// and any other value becomes a single element.
+func (d weakDecoder) items(src any) ([]any, error) {
+	switch v := src.(type) {
+	case []any:
+		return v, nil
+	case string:
+		if d.separator == "" {
+			return []any{v}, nil
+		} else if strings.TrimSpace(v) == "" {
+			return []any{}, nil
+		}
+		parts := strings.Split(v, d.separator)
+		items := make([]any, len(parts))
+		for i, part := range parts {
+			items[i] = strings.TrimSpace(part)
+		}
+		return items, nil
+	case map[string]any:
+		indexes := make([]int, 0, len(v))
+		byIndex := make(map[int]any, len(v))
+		for key, value := range v {
+			i, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("error convert to slice, unexpected key %q", key)
+			}
+			indexes = append(indexes, i)
+			byIndex[i] = value
+		}
+		sort.Ints(indexes)
+		items := make([]any, len(indexes))
+		for i, index := range indexes {
+			items[i] = byIndex[index]
+		}
+		return items, nil
+	default:
+		return []any{src}, nil
+	}
+}
+
+// weakFields returns the exported fields of a struct type, promoting those of embedded structs, named by the first
+// of the given tags present. Fields tagged with "-" are skipped.
+func weakFields(structType reflect.Type, tags []string) []weakField {
+	var fields []weakField
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		tag, hasTag := "", false
+		for _, name := range tags {
+			if tag, hasTag = field.Tag.Lookup(name); hasTag {
+				break
+			}
+		}
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && name == "" && !isWeakLeaf(field.Type) {
+			for _, promoted := range weakFields(field.Type, tags) {
+				promoted.index = append([]int{i}, promoted.index...)
+				fields = append(fields, promoted)
+			}
+			continue
+		} else if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, weakField{index: []int{i}, name: name, omitEmpty: strings.Contains(options, "omitempty")})
+	}
+	return fields
+}
+
+// isWeakLeaf reports whether values of the given type are scalars for the weak typed decoder, even when they are
+// structs or arrays, such as time.Time and UUID.
+func isWeakLeaf(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(Date{}), reflect.TypeOf(TimeOfDay{}), reflect.TypeOf(UUID{}),
+		reflect.TypeOf(ULID{}):
+		return true
+	}
+	if _, ok := lookupFlagSet(t); ok {
+		return true
+	}
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// weakString renders a scalar of a generic tree, using MarshalText when the type implements it and is not a
+// fmt.Stringer, and ToStringWithErr otherwise.
+func weakString(value reflect.Value) (string, error) {
+	if !implementsStringer(value.Type()) && value.Type().Implements(textMarshalerType) {
+		bs, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(bs), err
+	}
+	return ToStringWithErr(value.Interface())
+}
+
+// isWeakObject reports whether values of the given type, or of the type it points to, are decoded from objects.
+func isWeakObject(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return (t.Kind() == reflect.Struct || t.Kind() == reflect.Map) && !isWeakLeaf(t)
+}
+
+// isWeakScalar reports whether values of the given type are rendered as a single value by the flatteners of loosely
+// typed formats.
+func isWeakScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Pointer, reflect.Interface:
+		return isWeakLeaf(t) || t.Implements(textMarshalerType)
+	}
+	return true
+}
+
+// weakEntry is a scalar of a flattened value, with the path of names that leads to it.
+type weakEntry struct {
+	path  []string
+	value string
+}
+
+// weakFlatten flattens a struct or map into its scalars, in field order for structs and in key order for maps.
+// Fields are named by the first of the given tags present, zero fields with the omitempty option and nil pointers
+// are skipped, and slices of scalars are joined by the separator.
+func weakFlatten(value reflect.Value, tags []string, separator string) ([]weakEntry, error) {
+	var entries []weakEntry
+	var flatten func(path []string, value reflect.Value) error
+	flatten = func(path []string, value reflect.Value) error {
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+		if isWeakScalar(value.Type()) {
+			s, err := weakString(value)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, weakEntry{path: path, value: s})
+			return nil
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			for _, field := range weakFields(value.Type(), tags) {
+				fieldValue := value.FieldByIndex(field.index)
+				if field.omitEmpty && fieldValue.IsZero() {
+					continue
+				}
+				if err := flatten(appendPath(path, field.name), fieldValue); err != nil {
+					return err
+				}
+			}
+		case reflect.Map:
+			keys, byKey, err := sortedMapKeys(value)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if err = flatten(appendPath(path, key), byKey[key]); err != nil {
+					return err
+				}
+			}
+		default:
+			if value.Type().Elem().Kind() == reflect.Uint8 {
+				s, err := ToStringWithErr(value.Interface())
+				if err != nil {
+					return err
+				}
+				entries = append(entries, weakEntry{path: path, value: s})
+				return nil
+			}
+			items := make([]string, value.Len())
+			for i := range items {
+				elem := reflect.ValueOf(indirectValue(value.Index(i).Interface()))
+				if !elem.IsValid() || !isWeakScalar(elem.Type()) {
+					return fmt.Errorf("error convert %s, only slices of scalars are supported", strings.Join(path, "."))
+				}
+				s, err := weakString(elem)
+				if err != nil {
+					return err
+				}
+				items[i] = s
+			}
+			entries = append(entries, weakEntry{path: path, value: strings.Join(items, separator)})
+		}
+		return nil
+	}
+
+	if err := flatten(nil, value); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func appendPath(path []string, name string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), name)
+}
+
+// sortedMapKeys renders the keys of a map with ToStringWithErr, returning them sorted along with their values.
+func sortedMapKeys(value reflect.Value) ([]string, map[string]reflect.Value, error) {
+	keys := make([]string, 0, value.Len())
+	byKey := make(map[string]reflect.Value, value.Len())
+	for _, mapKey := range value.MapKeys() {
+		s, err := ToStringWithErr(mapKey.Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, s)
+		byKey[s] = value.MapIndex(mapKey)
+	}
+	sort.Strings(keys)
+	return keys, byKey, nil
+}
+
+// weakValueKey is the key under which weakTree keeps the value of a key that also has nested keys, such as
+// "logging.level" next to "logging.level.root". Struct fields never match it, so the value is skipped for them,
+// while scalar destinations read it.
+const weakValueKey = ""
+
+// weakTree nests the given flat values into a generic tree, splitting their keys by the separator, e.g. "db.host"
+// into {"db": {"host": ...}}. When the separator is empty, the tree is flat. A key that is also the prefix of other
+// keys keeps its value under weakValueKey, so "a=1" and "a.b=2" give {"a": {"": "1", "b": "2"}}.
+func weakTree(values map[string]string, separator string) (map[string]any, error) {
+	keys := make([]string, 0, len(values))
+	objects := map[string]bool{}
+	for key := range values {
+		keys = append(keys, key)
+		for i := 0; separator != "" && i < len(key); {
+			next := strings.Index(key[i:], separator)
+			if next < 0 {
+				break
+			}
+			i += next
+			objects[key[:i]] = true
+			i += len(separator)
+		}
+	}
+	sort.Strings(keys)
+
+	tree := make(map[string]any, len(values))
+	for _, key := range keys {
+		path := []string{key}
+		if separator != "" {
+			path = strings.Split(key, separator)
+		}
+		if objects[key] {
+			path = append(path, weakValueKey)
+		}
+		if err := weakTreeSet(tree, path, values[key]); err != nil {
+			return nil, fmt.Errorf("error convert %q: %w", key, err)
+		}
+	}
+	return tree, nil
+}
+
+// weakTreeSet sets the value at the given path of a generic tree, creating the objects along the way.
+func weakTreeSet(tree map[string]any, path []string, value any) error {
+	node := tree
+	for _, name := range path[:len(path)-1] {
+		child, ok := node[name].(map[string]any)
+		if !ok {
+			if _, exists := node[name]; exists {
+				return fmt.Errorf("%q is both a value and an object", name)
+			}
+			child = map[string]any{}
+			node[name] = child
+		}
+		node = child
+	}
+	name := path[len(path)-1]
+	if existing, exists := node[name]; exists {
+		if _, ok := existing.(map[string]any); ok {
+			return fmt.Errorf("%q is both a value and an object", name)
+		}
+		return fmt.Errorf("duplicate key %q", name)
+	}
+	node[name] = value
+	return nil
+}
+
+// weakDecodeMap fills dest, a pointer, with flat values read from a configuration format. A *map[string]string
+// receives the values as they are, any other destination receives the tree built by weakTree with the given
+// separator.
+func weakDecodeMap(values map[string]string, dest any, separator string, decoder weakDecoder) error {
+	reflectDest := reflect.ValueOf(dest)
+	if reflectDest.Kind() != reflect.Pointer {
+		return errors.New("dest is not a pointer")
+	} else if reflectDest.IsNil() {
+		return errors.New("dest is nil")
+	} else if m, ok := dest.(*map[string]string); ok {
+		*m = values
+		return nil
+	}
+
+	tree, err := weakTree(values, separator)
+	if err != nil {
+		return err
+	}
+	return decoder.decode(tree, reflectDest.Elem())
+}
+
+// weakFlattenStructOrMap flattens a struct or map, or a pointer to one, with weakFlatten, failing for any other
+// type with an error naming the format.
+func weakFlattenStructOrMap(a any, format string, tags []string, separator string) ([]weakEntry, error) {
+	if a == nil {
+		return nil, fmt.Errorf("error convert to %s, it is null", format)
+	}
+
+	reflectValue := reflect.ValueOf(indirectValue(a))
+	if reflectValue.Kind() != reflect.Struct && reflectValue.Kind() != reflect.Map || isWeakLeaf(reflectValue.Type()) {
+		return nil, fmt.Errorf("error convert to %s, unsupported type %s", format, reflectValue.Kind().String())
+	}
+	return weakFlatten(reflectValue, tags, separator)
+}
@@ -0,0 +1,341 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// xmlAttrPrefix marks the keys of an XML tree that hold attributes, e.g. "@id".
+	xmlAttrPrefix = "@"
+	// xmlTextKey is the key of an XML tree that holds the text of an element that also has attributes or children.
+	xmlTextKey = "#text"
+	// xmlDefaultRoot names the root element when a map has no single key to be used as root.
+	xmlDefaultRoot = "root"
+)
+
+// ToXMLString converts the given value to an XML string, panicking if the conversion fails.
+// See ToXMLStringWithErr.
+func ToXMLString(a any) string {
+	s, err := ToXMLStringWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ToXMLStringWithErr converts the given value to an XML string, the XML counterpart of ToStringWithErr for
+// structured values.
+//
+// Maps are rendered following the conventions of ToXMLMapWithErr: keys starting with "@" become attributes, the
+// "#text" key becomes the text of the element and slices become repeated elements. A map with a single key, whose
+// value is not a slice, is rendered with that key as the root element, any other map is wrapped in a "root"
+// element. Attributes and children are rendered in alphabetical order.
+//
+// Any other value is rendered with xml.Marshal, so structs follow their `xml` tags.
+//
+// Parameters:
+//   - a: The value of any type to be converted to XML.
+//
+// Returns:
+//   - string: The XML representation of the provided value, without the XML declaration.
+//   - error: An error is returned in case of failure to convert.
+//
+// Example:
+//
+//	type Item struct {
+//		XMLName xml.Name `xml:"item"`
+//		Code    string   `xml:"code,attr"`
+//		Price   float64  `xml:"price"`
+//	}
+//	s, _ := ToXMLStringWithErr(Item{Code: "A1", Price: 9.9})
+//	fmt.Println(s) // <item code="A1"><price>9.9</price></item>
+//
+//	s, _ = ToXMLStringWithErr(map[string]any{"item": map[string]any{"@code": "A1", "price": 9.9}})
+//	fmt.Println(s) // <item code="A1"><price>9.9</price></item>
+func ToXMLStringWithErr(a any) (string, error) {
+	if a == nil {
+		return "", errors.New("error convert to XML, it is null")
+	}
+
+	reflectValue := reflect.ValueOf(indirectValue(a))
+	if reflectValue.Kind() != reflect.Map {
+		bs, err := xml.Marshal(a)
+		return string(bs), err
+	} else if reflectValue.Type().Key().Kind() != reflect.String {
+		return "", fmt.Errorf("error convert to XML, unsupported map key type %s", reflectValue.Type().Key().String())
+	}
+
+	name, value := xmlDefaultRoot, reflectValue
+	if keys := reflectValue.MapKeys(); len(keys) == 1 && !isXMLSpecialKey(keys[0].String()) {
+		single := reflectValue.MapIndex(keys[0])
+		if !isXMLRepeated(reflect.ValueOf(indirectValue(single.Interface()))) {
+			name, value = keys[0].String(), single
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+	if err := encodeXMLElement(encoder, name, value); err != nil {
+		return "", err
+	} else if err = encoder.Flush(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ToDestFromXML decodes the given XML into dest, panicking if decoding fails. See ToDestFromXMLWithErr.
+func ToDestFromXML(a, dest any) {
+	if err := ToDestFromXMLWithErr(a, dest); err != nil {
+		panic(err)
+	}
+}
+
+// ToDestFromXMLWithErr converts the given value to a byte slice using ToBytesWithErr and decodes it as XML into
+// dest, the XML counterpart of ToDestWithErr for structured destinations.
+//
+// A *map[string]any destination receives the tree described in ToXMLMapWithErr. Any other destination is filled
+// with xml.Unmarshal rules, so structs follow their `xml` tags. Documents declaring a legacy encoding, e.g.
+// <?xml version="1.0" encoding="ISO-8859-1"?>, are decoded with the charsets supported by ParseCharset.
+//
+// Example:
+//
+//	type Item struct {
+//		Code  string  `xml:"code,attr"`
+//		Price float64 `xml:"price"`
+//	}
+//	var item Item
+//	err := ToDestFromXMLWithErr(`<item code="A1"><price>9.9</price></item>`, &item)
+//	fmt.Println(item, err) // {A1 9.9} <nil>
+func ToDestFromXMLWithErr(a, dest any) error {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return err
+	}
+
+	if m, ok := dest.(*map[string]any); ok {
+		tree, err := decodeXMLTree(bs)
+		if err != nil {
+			return err
+		}
+		*m = tree
+		return nil
+	}
+	return newXMLDecoder(bs).Decode(dest)
+}
+
+// ToXMLMap converts the given XML to a generic tree, panicking if decoding fails. See ToXMLMapWithErr.
+func ToXMLMap(a any) map[string]any {
+	m, err := ToXMLMapWithErr(a)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ToXMLMapWithErr converts the given value to a byte slice using ToBytesWithErr and decodes it as XML into a
+// generic tree, for documents without a matching struct.
+//
+// The tree holds a single key, the name of the root element, and follows these conventions:
+//   - An element with only text, or empty, becomes its text as a string.
+//   - An element with attributes or children becomes a map[string]any.
+//   - Attributes are stored under their name prefixed by "@", e.g. "@id".
+//   - The text of an element that has attributes or children is stored under "#text".
+//   - Repeated children become a []any in document order.
+//
+// Namespace prefixes are dropped from element names, while namespace declarations are kept as attributes, e.g.
+// "@xmlns". Comments and processing instructions are ignored.
+//
+// Example:
+//
+//	m, _ := ToXMLMapWithErr(`<nfe id="1"><item>A</item><item>B</item><total>10</total></nfe>`)
+//	fmt.Println(m) // map[nfe:map[@id:1 item:[A B] total:10]]
+func ToXMLMapWithErr(a any) (map[string]any, error) {
+	bs, err := ToBytesWithErr(a)
+	if err != nil {
+		return nil, err
+	}
+	return decodeXMLTree(bs)
+}
+
+type xmlTreeNode struct {
+	name   string
+	fields map[string]any
+	text   strings.Builder
+}
+
+func (n *xmlTreeNode) value() any {
+	if len(n.fields) == 0 {
+		return n.text.String()
+	}
+	if text := strings.TrimSpace(n.text.String()); text != "" {
+		n.fields[xmlTextKey] = text
+	}
+	return n.fields
+}
+
+func decodeXMLTree(bs []byte) (map[string]any, error) {
+	decoder := newXMLDecoder(bs)
+
+	var stack []*xmlTreeNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error convert from XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlTreeNode{name: t.Name.Local, fields: map[string]any{}}
+			for _, attr := range t.Attr {
+				name := attr.Name.Local
+				if attr.Name.Space == "xmlns" {
+					name = "xmlns:" + name
+				}
+				node.fields[xmlAttrPrefix+name] = attr.Value
+			}
+			stack = append(stack, node)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return map[string]any{node.name: node.value()}, nil
+			}
+			addXMLTreeChild(stack[len(stack)-1].fields, node.name, node.value())
+		}
+	}
+	return nil, errors.New("error convert from XML, no root element found")
+}
+
+func addXMLTreeChild(fields map[string]any, name string, value any) {
+	switch existing := fields[name].(type) {
+	case nil:
+		fields[name] = value
+	case []any:
+		fields[name] = append(existing, value)
+	default:
+		fields[name] = []any{existing, value}
+	}
+}
+
+func newXMLDecoder(bs []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(StripBOM(bs)))
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		charset, err := ParseCharset(label)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		s, err := ToStringFromCharsetWithErr(encoded, charset, InvalidByteReplace)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(s), nil
+	}
+	return decoder
+}
+
+func encodeXMLElement(encoder *xml.Encoder, name string, value reflect.Value) error {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return encodeXMLTokens(encoder, xml.StartElement{Name: xml.Name{Local: name}})
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("error convert to XML, unsupported map key type %s", value.Type().Key().String())
+		}
+		start := xml.StartElement{Name: xml.Name{Local: name}}
+		var text string
+		var children []reflect.Value
+		for _, key := range value.MapKeys() {
+			switch k := key.String(); {
+			case k == xmlTextKey:
+				s, err := ToStringWithErr(value.MapIndex(key).Interface())
+				if err != nil {
+					return err
+				}
+				text = s
+			case strings.HasPrefix(k, xmlAttrPrefix):
+				s, err := ToStringWithErr(value.MapIndex(key).Interface())
+				if err != nil {
+					return err
+				}
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: k[len(xmlAttrPrefix):]}, Value: s})
+			default:
+				children = append(children, key)
+			}
+		}
+		sort.Slice(start.Attr, func(i, j int) bool { return start.Attr[i].Name.Local < start.Attr[j].Name.Local })
+		sort.Slice(children, func(i, j int) bool { return children[i].String() < children[j].String() })
+
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		} else if err = encoder.EncodeToken(xml.CharData(text)); err != nil {
+			return err
+		}
+		for _, child := range children {
+			if err := encodeXMLElement(encoder, child.String(), value.MapIndex(child)); err != nil {
+				return err
+			}
+		}
+		return encoder.EncodeToken(start.End())
+	case reflect.Slice, reflect.Array:
+		if isXMLRepeated(value) {
+			for i := 0; i < value.Len(); i++ {
+				if err := encodeXMLElement(encoder, name, value.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	s, err := ToStringWithErr(value.Interface())
+	if err != nil {
+		return err
+	}
+	return encodeXMLTokens(encoder, xml.StartElement{Name: xml.Name{Local: name}}, xml.CharData(s))
+}
+
+func encodeXMLTokens(encoder *xml.Encoder, start xml.StartElement, tokens ...xml.Token) error {
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := encoder.EncodeToken(token); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+func isXMLSpecialKey(key string) bool {
+	return key == xmlTextKey || strings.HasPrefix(key, xmlAttrPrefix)
+}
+
+func isXMLRepeated(value reflect.Value) bool {
+	return (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() != reflect.Uint8
+}
+
+func isXMLDocument(bs []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(StripBOM(bs)), []byte("<"))
+}
@@ -0,0 +1,137 @@
+package converter
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+type xmlTestItem struct {
+	XMLName xml.Name `xml:"item"`
+	Code    string   `xml:"code,attr"`
+	Price   float64  `xml:"price"`
+}
+
+// xmlTestKey is a named map key type, whose values are not assignable from plain strings.
+type xmlTestKey string
+
+func TestToXMLStringWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    string
+		wantErr bool
+	}{
+		{"Struct", xmlTestItem{Code: "A1", Price: 9.9}, `<item code="A1"><price>9.9</price></item>`, false},
+		{"Struct pointer", &xmlTestItem{Code: "B2"}, `<item code="B2"><price>0</price></item>`, false},
+		{"Map single root", map[string]any{"item": map[string]any{"@code": "A1", "price": 9.9}},
+			`<item code="A1"><price>9.9</price></item>`, false},
+		{"Map text and repeated", map[string]any{"nfe": map[string]any{"#text": "x", "det": []any{"1", "2"}}},
+			`<nfe>x<det>1</det><det>2</det></nfe>`, false},
+		{"Map wrapped in root", map[string]any{"b": 2, "a": "1 < 2"}, `<root><a>1 &lt; 2</a><b>2</b></root>`, false},
+		{"Map single slice", map[string][]int{"n": {1, 2}}, `<root><n>1</n><n>2</n></root>`, false},
+		{"Map nil value", map[string]any{"empty": nil}, `<empty></empty>`, false},
+		{"Map named key type", map[xmlTestKey]any{"b": 2, "a": map[xmlTestKey]int{"y": 1, "x": 0}},
+			`<root><a><x>0</x><y>1</y></a><b>2</b></root>`, false},
+		{"Unsupported key", map[int]string{1: "a"}, "", true},
+		{"Nil", nil, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToXMLStringWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToXMLStringWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ToXMLStringWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToXMLMapWithErr(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:  "Attributes, repeated and text",
+			input: `<?xml version="1.0"?><nfe id="1"><item>A</item><item>B</item><obs lang="pt">ok</obs><total/></nfe>`,
+			want: map[string]any{"nfe": map[string]any{
+				"@id":   "1",
+				"item":  []any{"A", "B"},
+				"obs":   map[string]any{"@lang": "pt", "#text": "ok"},
+				"total": "",
+			}},
+		},
+		{
+			name: "Namespaces",
+			input: `<NFe xmlns="http://www.portalfiscal.inf.br/nfe" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+				<ds:Signature>sig</ds:Signature>
+			</NFe>`,
+			want: map[string]any{"NFe": map[string]any{
+				"@xmlns":    "http://www.portalfiscal.inf.br/nfe",
+				"@xmlns:ds": "http://www.w3.org/2000/09/xmldsig#",
+				"Signature": "sig",
+			}},
+		},
+		{
+			name:  "Latin-1 declaration",
+			input: []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><city>S\xe3o Paulo</city>"),
+			want:  map[string]any{"city": "São Paulo"},
+		},
+		{name: "Malformed", input: `<a><b></a>`, wantErr: true},
+		{name: "Empty", input: ``, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToXMLMapWithErr(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToXMLMapWithErr() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ToXMLMapWithErr() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToDestFromXMLWithErr(t *testing.T) {
+	var item xmlTestItem
+	if err := ToDestFromXMLWithErr(`<item code="A1"><price>9.9</price></item>`, &item); err != nil {
+		t.Fatalf("ToDestFromXMLWithErr() error = %v", err)
+	}
+	if item.Code != "A1" || item.Price != 9.9 {
+		t.Errorf("ToDestFromXMLWithErr() = %+v", item)
+	}
+
+	var viaDest xmlTestItem
+	if err := ToDestWithErr("\n  <item code=\"B2\"><price>1</price></item>", &viaDest); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if viaDest.Code != "B2" || viaDest.Price != 1 {
+		t.Errorf("ToDestWithErr() = %+v", viaDest)
+	}
+
+	var tree map[string]any
+	if err := ToDestWithErr(`<a><b>1</b></a>`, &tree); err != nil {
+		t.Fatalf("ToDestWithErr() error = %v", err)
+	}
+	if !reflect.DeepEqual(tree, map[string]any{"a": map[string]any{"b": "1"}}) {
+		t.Errorf("ToDestWithErr() = %v", tree)
+	}
+
+	var roundTrip map[string]any
+	ToDestFromXML(ToXMLString(tree), &roundTrip)
+	if !reflect.DeepEqual(roundTrip, tree) {
+		t.Errorf("round trip = %v, want %v", roundTrip, tree)
+	}
+
+	if err := ToDestFromXMLWithErr(`<item><price>abc</price></item>`, &item); err == nil {
+		t.Error("ToDestFromXMLWithErr() expected error")
+	}
+}